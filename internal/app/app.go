@@ -14,8 +14,11 @@ import (
 
 	"github.com/b0pof/avito-internship/internal/config"
 	delivery "github.com/b0pof/avito-internship/internal/delivery/http"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
 	"github.com/b0pof/avito-internship/internal/pkg/middleware"
+	"github.com/b0pof/avito-internship/internal/pkg/storage"
 	"github.com/b0pof/avito-internship/internal/repository"
+	"github.com/b0pof/avito-internship/internal/scheduler"
 	"github.com/b0pof/avito-internship/internal/server"
 	"github.com/b0pof/avito-internship/internal/usecase"
 	"github.com/b0pof/avito-internship/pkg/logger"
@@ -33,10 +36,11 @@ const (
 )
 
 type App struct {
-	config *config.Config
-	server *server.Server
-	router *mux.Router
-	logger *slog.Logger
+	config    *config.Config
+	server    *server.Server
+	scheduler *scheduler.Scheduler
+	router    *mux.Router
+	logger    *slog.Logger
 }
 
 func MustInit() *App {
@@ -49,8 +53,6 @@ func MustInit() *App {
 		cfg.Server.ServerAddr = _addr
 	}
 
-	fmt.Println(cfg)
-
 	// Logger
 
 	log := logger.NewLogger(_env)
@@ -82,23 +84,41 @@ func MustInit() *App {
 
 	// Layers
 
+	blobs, err := storage.NewLocal(cfg.Storage)
+	if err != nil {
+		panic("storage init error: " + err.Error())
+	}
+	tokens := auth.NewTokenManager(cfg.Auth)
 	repo := repository.New(pgClient)
-	uc := usecase.New(repo)
+	uc := usecase.New(repo, tokens, blobs)
 	h := delivery.NewHandler(uc)
 	h.InitRouter(apiRouter)
 
 	// Middleware
 	r.Use(middleware.NewLoggingMiddleware(log))
+	r.Use(middleware.NewAuthMiddleware(tokens, uc))
+
+	// Scheduler
+
+	sched := scheduler.New(cfg.Scheduler, uc, log)
 
 	return &App{
-		config: cfg,
-		server: srv,
-		router: r,
-		logger: log,
+		config:    cfg,
+		server:    srv,
+		scheduler: sched,
+		router:    r,
+		logger:    log,
 	}
 }
 
 func (a *App) Run() {
+	schedCtx, stopScheduler := context.WithCancel(context.Background())
+	schedDone := make(chan struct{})
+	go func() {
+		defer close(schedDone)
+		a.scheduler.Run(schedCtx)
+	}()
+
 	go func() {
 		a.logger.Info("server is running...")
 		if err := a.server.Run(); err != nil {
@@ -120,4 +140,11 @@ func (a *App) Run() {
 	if err := a.server.Stop(ctx); err != nil {
 		a.logger.Error(fmt.Sprintf("HTTP server shutdown error: %v", err))
 	}
+
+	stopScheduler()
+	select {
+	case <-schedDone:
+	case <-ctx.Done():
+		a.logger.Error("scheduler shutdown timeout")
+	}
 }
@@ -2,14 +2,18 @@ package config
 
 import (
 	"log"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   Server
-	Postgres Postgres
+	Server    Server
+	Postgres  Postgres
+	Auth      Auth
+	Scheduler Scheduler
+	Storage   Storage
 }
 
 type Server struct {
@@ -20,6 +24,19 @@ type Postgres struct {
 	DSN string `env:"POSTGRES_CONN" env-required:"true"`
 }
 
+type Auth struct {
+	Secret   string        `env:"AUTH_SECRET" env-required:"true"`
+	TokenTTL time.Duration `env:"AUTH_TOKEN_TTL" env-default:"24h"`
+}
+
+type Scheduler struct {
+	Interval time.Duration `env:"SCHEDULER_INTERVAL" env-default:"1m"`
+}
+
+type Storage struct {
+	Dir string `env:"STORAGE_DIR" env-default:"./data/attachments"`
+}
+
 func MustLoad() *Config {
 	err := godotenv.Load()
 	if err != nil {
@@ -1,11 +1,56 @@
 package dto
 
-type ErrResponse struct {
-	Reason string `json:"reason"`
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+// Problem is an RFC 7807 problem details object. Reason repeats Detail for
+// clients of the earlier error format.
+type Problem struct {
+	Type     string             `json:"type"`
+	Title    string             `json:"title"`
+	Status   int                `json:"status"`
+	Detail   string             `json:"detail"`
+	Instance string             `json:"instance,omitempty"`
+	Code     string             `json:"code"`
+	Reason   string             `json:"reason"`
+	Details  []model.FieldError `json:"details,omitempty"`
 }
 
-func NewErrResponse(err error) *ErrResponse {
-	return &ErrResponse{
-		Reason: err.Error(),
+// NewProblem describes the error for the client in lang. Errors other than
+// model.Error are reported as internal, so that their text does not leak.
+func NewProblem(err error, instance string, lang model.Language) *Problem {
+	var e *model.Error
+	if !errors.As(err, &e) {
+		e = model.ErrInternal
+	}
+	detail := e.Localize(lang)
+	return &Problem{
+		Type:     "/problems/" + e.Code,
+		Title:    http.StatusText(e.Status),
+		Status:   e.Status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     e.Code,
+		Reason:   detail,
+		Details:  e.LocalizeDetails(lang),
 	}
 }
+
+// PageResponse is a page of a list requested by cursor. NextCursor is null
+// on the last page.
+type PageResponse[T any] struct {
+	Items      []T     `json:"items"`
+	NextCursor *string `json:"nextCursor"`
+	Total      *int    `json:"total,omitempty"`
+}
+
+// SealedBidsResponse replaces the list of bids of a sealed tender until submissions close.
+type SealedBidsResponse struct {
+	Sealed bool `json:"sealed"`
+	Count  int  `json:"count"`
+}
@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	organizationID, err := helper.ParseOrganizationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, err := helper.ParseAPIKeyFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input.OrganizationID = organizationID
+	key, err := h.uc.CreateAPIKey(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, key)
+}
+
+func (h *Handler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	organizationID, err := helper.ParseOrganizationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	keys, err := h.uc.GetAPIKeys(ctx, usecase.GetAPIKeysInput{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, keys)
+}
+
+func (h *Handler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	organizationID, err := helper.ParseOrganizationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	keyID, err := helper.ParseKeyID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	key, err := h.uc.RevokeAPIKey(ctx, usecase.RevokeAPIKeyInput{
+		OrganizationID: organizationID,
+		KeyID:          keyID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, key)
+}
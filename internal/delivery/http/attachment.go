@@ -0,0 +1,223 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) parseAttachmentUpload(w http.ResponseWriter, r *http.Request, ownerID string) (usecase.AddAttachmentInput, bool) {
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return usecase.AddAttachmentInput{}, false
+	}
+	part, err := helper.ParseAttachmentUpload(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return usecase.AddAttachmentInput{}, false
+	}
+	return usecase.AddAttachmentInput{
+		OwnerID:          ownerID,
+		Filename:         part.FileName(),
+		ContentType:      part.Header.Get("Content-Type"),
+		Body:             part,
+		ExpectedVersions: expectedVersions,
+	}, true
+}
+
+func (h *Handler) AddTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, ok := h.parseAttachmentUpload(w, r, tenderID)
+	if !ok {
+		return
+	}
+	tender, err := h.uc.AddTenderAttachment(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.SetETag(w, tender.Version)
+	helper.Respond(r.Context(), w, 200, tender)
+}
+
+func (h *Handler) RemoveTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	attachmentID, err := helper.ParseAttachmentID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	tender, err := h.uc.RemoveTenderAttachment(ctx, usecase.RemoveAttachmentInput{
+		OwnerID:          tenderID,
+		AttachmentID:     attachmentID,
+		ExpectedVersions: expectedVersions,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.SetETag(w, tender.Version)
+	helper.Respond(r.Context(), w, 200, tender)
+}
+
+func (h *Handler) GetTenderAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	version, err := helper.ParseAttachmentsVersion(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	attachments, err := h.uc.GetTenderAttachments(ctx, usecase.GetAttachmentsInput{
+		OwnerID: tenderID,
+		Version: version,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, attachments)
+}
+
+func (h *Handler) DownloadTenderAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	attachmentID, err := helper.ParseAttachmentID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	content, err := h.uc.OpenTenderAttachment(ctx, usecase.GetAttachmentInput{
+		OwnerID:      tenderID,
+		AttachmentID: attachmentID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	defer content.Body.Close()
+	helper.RespondFile(ctx, w, content.Attachment, content.Body)
+}
+
+func (h *Handler) AddBidAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, ok := h.parseAttachmentUpload(w, r, bidID)
+	if !ok {
+		return
+	}
+	bid, err := h.uc.AddBidAttachment(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.SetETag(w, strconv.Itoa(bid.Version))
+	helper.Respond(r.Context(), w, 200, bid)
+}
+
+func (h *Handler) RemoveBidAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	attachmentID, err := helper.ParseAttachmentID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	bid, err := h.uc.RemoveBidAttachment(ctx, usecase.RemoveAttachmentInput{
+		OwnerID:          bidID,
+		AttachmentID:     attachmentID,
+		ExpectedVersions: expectedVersions,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.SetETag(w, strconv.Itoa(bid.Version))
+	helper.Respond(r.Context(), w, 200, bid)
+}
+
+func (h *Handler) GetBidAttachments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	version, err := helper.ParseAttachmentsVersion(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	attachments, err := h.uc.GetBidAttachments(ctx, usecase.GetAttachmentsInput{
+		OwnerID: bidID,
+		Version: version,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, attachments)
+}
+
+func (h *Handler) DownloadBidAttachment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	attachmentID, err := helper.ParseAttachmentID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	content, err := h.uc.OpenBidAttachment(ctx, usecase.GetAttachmentInput{
+		OwnerID:      bidID,
+		AttachmentID: attachmentID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	defer content.Body.Close()
+	helper.RespondFile(ctx, w, content.Attachment, content.Body)
+}
@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) GetAuction(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lotID, err := helper.ParseLotFilter(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	auction, err := h.uc.GetAuction(ctx, usecase.GetAuctionInput{
+		TenderID: tenderID,
+		LotID:    lotID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, auction)
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+func (h *Handler) GetAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	limit, offset, err := helper.ParseLimitOffset(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	entityType, entityID, err := helper.ParseAuditEntity(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	events, err := h.uc.GetAuditEvents(ctx, repository.GetAuditEventsInput{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Limit:      limit,
+		Offset:     offset,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, events)
+}
@@ -0,0 +1,22 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+)
+
+func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	input, err := helper.ParseLoginFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	token, err := h.uc.Login(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, token)
+}
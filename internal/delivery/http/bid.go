@@ -2,11 +2,9 @@ package http
 
 import (
 	"net/http"
-
-	"github.com/pkg/errors"
+	"strconv"
 
 	"github.com/b0pof/avito-internship/internal/delivery/dto"
-	"github.com/b0pof/avito-internship/internal/model"
 	"github.com/b0pof/avito-internship/internal/pkg/helper"
 	"github.com/b0pof/avito-internship/internal/repository"
 	"github.com/b0pof/avito-internship/internal/usecase"
@@ -16,233 +14,249 @@ func (h *Handler) CreateBid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	bid, err := helper.ParseBidFromBody(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
 	createdTender, err := h.uc.CreateBid(ctx, bid)
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights) || errors.Is(err, model.ErrNoOrganizationFound):
-			status = 403
-		case errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		case errors.Is(err, model.ErrInternal):
-			status = 500
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, strconv.Itoa(createdTender.Version))
 	helper.Respond(r.Context(), w, 200, createdTender)
 }
 
 func (h *Handler) GetMyBids(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	limit, offset, err := helper.ParseLimitOffset(r)
+	page, err := helper.ParsePagination(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	username := helper.ParseUsername(r)
-	tenders, err := h.uc.GetMyBids(ctx, usecase.GetMyBidsInput{
-		Limit:    limit,
-		Offset:   offset,
-		Username: username,
+	bids, err := h.uc.GetMyBids(ctx, usecase.GetMyBidsInput{
+		Pagination: page,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrInternal):
-			status = 500
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	helper.Respond(r.Context(), w, 200, tenders)
+	helper.RespondPage(w, r, bids, page)
 }
 
 func (h *Handler) GetTenderBids(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	limit, offset, err := helper.ParseLimitOffset(r)
+	tenderID, err := helper.ParseTenderID(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	username := helper.ParseUsername(r)
-	if username == "" {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(model.ErrInvalidQueryParam))
+	lotID, err := helper.ParseLotFilter(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	page, err := helper.ParsePagination(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
 		return
 	}
-	tenderID := helper.ParseTenderID(r)
-	tenders, err := h.uc.GetTenderBids(ctx, repository.GetTenderBidsInput{
-		Limit:    limit,
-		Offset:   offset,
-		Username: username,
-		TenderID: tenderID,
+	sort, err := helper.ParseBidSort(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	bids, err := h.uc.GetTenderBids(ctx, repository.GetTenderBidsInput{
+		TenderID:   tenderID,
+		LotID:      lotID,
+		Sort:       sort,
+		Pagination: page,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrNoBidsFound) || errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		case errors.Is(err, model.ErrInternal):
-			status = 500
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
-		return
-	}
-	helper.Respond(r.Context(), w, 200, tenders)
+		helper.RespondError(w, r, err)
+		return
+	}
+	if bids.Sealed {
+		helper.Respond(r.Context(), w, 200, dto.SealedBidsResponse{
+			Sealed: true,
+			Count:  bids.Count,
+		})
+		return
+	}
+	helper.RespondPage(w, r, bids.Page, page)
 }
 
 func (h *Handler) GetBidStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	bidID := helper.ParseBidID(r)
-	username := helper.ParseUsername(r)
-	stat, err := h.uc.GetBidStatus(ctx, usecase.GetBidStatusInput{
-		BidID:    bidID,
-		Username: username,
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	bid, err := h.uc.GetBidStatus(ctx, usecase.GetBidStatusInput{
+		BidID: bidID,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrNoBidFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	helper.Respond(r.Context(), w, 200, stat)
+	helper.SetETag(w, strconv.Itoa(bid.Version))
+	helper.Respond(r.Context(), w, 200, bid.Status)
 }
 
 func (h *Handler) UpdateBidStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	bidID := helper.ParseBidID(r)
-	username := helper.ParseUsername(r)
-	stat := helper.ParseStatus(r)
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	stat, err := helper.ParseBidStatus(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	bid, err := h.uc.UpdateBidStatus(ctx, usecase.UpdateBidStatusInput{
-		BidID:    bidID,
-		Status:   stat,
-		Username: username,
+		BidID:            bidID,
+		Status:           stat,
+		ExpectedVersions: expectedVersions,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrInvalidAttributeValue):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrNoBidFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, strconv.Itoa(bid.Version))
 	helper.Respond(r.Context(), w, 200, bid)
 }
 
 func (h *Handler) SubmitDecision(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	bidID := helper.ParseBidID(r)
-	username := helper.ParseUsername(r)
-	decision := helper.ParseDecision(r)
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	decision, err := helper.ParseDecision(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	bid, err := h.uc.SubmitDecision(ctx, usecase.SubmitDecisionInput{
 		BidID:    bidID,
-		Username: username,
 		Decision: decision,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrWrongDecision):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrNoBidFound) || errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, strconv.Itoa(bid.Version))
 	helper.Respond(r.Context(), w, 200, bid)
 }
 
 func (h *Handler) UpdateBid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	bidID := helper.ParseBidID(r)
-	username := helper.ParseUsername(r)
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	info, err := helper.ParseUpdateBidInfo(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
 		return
 	}
 	updBid, err := h.uc.UpdateBid(ctx, usecase.UpdateBidInput{
-		BidID:       bidID,
-		Username:    username,
-		Name:        info.Name,
-		Description: info.Description,
+		BidID:            bidID,
+		Name:             info.Name,
+		Description:      info.Description,
+		Price:            info.Price,
+		Currency:         info.Currency,
+		ExpectedVersions: expectedVersions,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrInvalidAttributeValue):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrNoBidFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, strconv.Itoa(updBid.Version))
 	helper.Respond(r.Context(), w, 200, updBid)
 }
 
 func (h *Handler) RollbackBid(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	bidID := helper.ParseBidID(r)
-	username := helper.ParseUsername(r)
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	version, err := helper.ParseVersion(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
 		return
 	}
 	updBid, err := h.uc.RollbackBid(ctx, usecase.RollbackBidInput{
-		BidID:    bidID,
-		Username: username,
-		Version:  version,
+		BidID:            bidID,
+		Version:          version,
+		ExpectedVersions: expectedVersions,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrInvalidAttributeValue):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrNoBidFound) || errors.Is(err, model.ErrNoSuchVersion):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, strconv.Itoa(updBid.Version))
 	helper.Respond(r.Context(), w, 200, updBid)
 }
+
+func (h *Handler) GetBidVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	versions, err := h.uc.GetBidVersions(ctx, usecase.GetBidVersionsInput{
+		BidID: bidID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, versions)
+}
+
+func (h *Handler) GetBidDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	from, to, err := helper.ParseDiffVersions(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	diff, err := h.uc.GetBidDiff(ctx, usecase.GetBidDiffInput{
+		BidID: bidID,
+		From:  from,
+		To:    to,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, diff)
+}
@@ -0,0 +1,101 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) GetTenderCriteria(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	criteria, err := h.uc.GetTenderCriteria(ctx, usecase.GetTenderCriteriaInput{
+		TenderID: tenderID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, criteria)
+}
+
+func (h *Handler) SetTenderCriteria(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	criteria, err := helper.ParseCriteriaFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	result, err := h.uc.SetTenderCriteria(ctx, usecase.SetTenderCriteriaInput{
+		TenderID: tenderID,
+		Criteria: criteria,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, result)
+}
+
+func (h *Handler) SubmitScores(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	scores, err := helper.ParseScoresFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	result, err := h.uc.SubmitScores(ctx, usecase.SubmitScoresInput{
+		BidID:  bidID,
+		Scores: scores,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, result)
+}
+
+func (h *Handler) GetTenderRanking(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lotID, err := helper.ParseLotFilter(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	limit, offset, err := helper.ParseLimitOffset(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	ranking, err := h.uc.GetTenderRanking(ctx, usecase.GetTenderRankingInput{
+		TenderID: tenderID,
+		LotID:    lotID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, ranking)
+}
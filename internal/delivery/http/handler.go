@@ -19,15 +19,40 @@ func NewHandler(uc usecase.IUsecase) *Handler {
 }
 
 func (h *Handler) InitRouter(r *mux.Router) {
+	r.Handle("/auth/login", http.HandlerFunc(h.Login)).Methods("POST", "OPTIONS")
+	r.Handle("/audit", http.HandlerFunc(h.GetAuditEvents)).Methods("GET", "OPTIONS")
+
 	tenders := r.PathPrefix("/tenders").Subrouter()
 	{
 		tenders.Handle("", http.HandlerFunc(h.GetTenders)).Methods("GET", "OPTIONS")
 		tenders.Handle("/new", http.HandlerFunc(h.CreateTender)).Methods("POST", "OPTIONS")
 		tenders.Handle("/my", http.HandlerFunc(h.GetMyTenders)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}", http.HandlerFunc(h.GetTender)).Methods("GET", "OPTIONS")
 		tenders.Handle("/{tenderId}/status", http.HandlerFunc(h.GetTenderStatus)).Methods("GET", "OPTIONS")
 		tenders.Handle("/{tenderId}/status", http.HandlerFunc(h.UpdateTenderStatus)).Methods("PUT", "OPTIONS")
 		tenders.Handle("/{tenderId}/edit", http.HandlerFunc(h.UpdateTender)).Methods("PATCH", "OPTIONS")
 		tenders.Handle("/{tenderId}/rollback/{version}", http.HandlerFunc(h.RollbackTender)).Methods("PUT", "OPTIONS")
+		tenders.Handle("/{tenderId}/versions", http.HandlerFunc(h.GetTenderVersions)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/diff", http.HandlerFunc(h.GetTenderDiff)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/criteria", http.HandlerFunc(h.GetTenderCriteria)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/criteria", http.HandlerFunc(h.SetTenderCriteria)).Methods("PUT", "OPTIONS")
+		tenders.Handle("/{tenderId}/ranking", http.HandlerFunc(h.GetTenderRanking)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/auction", http.HandlerFunc(h.GetAuction)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/lots", http.HandlerFunc(h.GetTenderLots)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/lots", http.HandlerFunc(h.CreateLot)).Methods("POST", "OPTIONS")
+		tenders.Handle("/{tenderId}/lots/{lotId}/edit", http.HandlerFunc(h.UpdateLot)).Methods("PATCH", "OPTIONS")
+		tenders.Handle("/{tenderId}/lots/{lotId}/rollback/{version}", http.HandlerFunc(h.RollbackLot)).Methods("PUT", "OPTIONS")
+		tenders.Handle("/{tenderId}/lots/{lotId}/versions", http.HandlerFunc(h.GetLotVersions)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/invitations", http.HandlerFunc(h.GetTenderInvitations)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/invitations", http.HandlerFunc(h.CreateInvitation)).Methods("POST", "OPTIONS")
+		tenders.Handle("/{tenderId}/invitations/{invitationId}", http.HandlerFunc(h.RemoveInvitation)).Methods("DELETE", "OPTIONS")
+		tenders.Handle("/{tenderId}/questions", http.HandlerFunc(h.GetTenderQuestions)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/questions", http.HandlerFunc(h.AskQuestion)).Methods("POST", "OPTIONS")
+		tenders.Handle("/{tenderId}/questions/{questionId}/answer", http.HandlerFunc(h.AnswerQuestion)).Methods("PUT", "OPTIONS")
+		tenders.Handle("/{tenderId}/attachments", http.HandlerFunc(h.GetTenderAttachments)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/attachments", http.HandlerFunc(h.AddTenderAttachment)).Methods("POST", "OPTIONS")
+		tenders.Handle("/{tenderId}/attachments/{attachmentId}", http.HandlerFunc(h.DownloadTenderAttachment)).Methods("GET", "OPTIONS")
+		tenders.Handle("/{tenderId}/attachments/{attachmentId}", http.HandlerFunc(h.RemoveTenderAttachment)).Methods("DELETE", "OPTIONS")
 	}
 
 	bids := r.PathPrefix("/bids").Subrouter()
@@ -40,5 +65,24 @@ func (h *Handler) InitRouter(r *mux.Router) {
 		bids.Handle("/{bidId}/submit_decision", http.HandlerFunc(h.SubmitDecision)).Methods("PUT", "OPTIONS")
 		bids.Handle("/{bidId}/edit", http.HandlerFunc(h.UpdateBid)).Methods("PATCH", "OPTIONS")
 		bids.Handle("/{bidId}/rollback/{version}", http.HandlerFunc(h.RollbackBid)).Methods("PUT", "OPTIONS")
+		bids.Handle("/{bidId}/versions", http.HandlerFunc(h.GetBidVersions)).Methods("GET", "OPTIONS")
+		bids.Handle("/{bidId}/diff", http.HandlerFunc(h.GetBidDiff)).Methods("GET", "OPTIONS")
+		bids.Handle("/{bidId}/feedback", http.HandlerFunc(h.SubmitFeedback)).Methods("PUT", "OPTIONS")
+		bids.Handle("/{bidId}/scores", http.HandlerFunc(h.SubmitScores)).Methods("PUT", "OPTIONS")
+		bids.Handle("/{bidId}/attachments", http.HandlerFunc(h.GetBidAttachments)).Methods("GET", "OPTIONS")
+		bids.Handle("/{bidId}/attachments", http.HandlerFunc(h.AddBidAttachment)).Methods("POST", "OPTIONS")
+		bids.Handle("/{bidId}/attachments/{attachmentId}", http.HandlerFunc(h.DownloadBidAttachment)).Methods("GET", "OPTIONS")
+		bids.Handle("/{bidId}/attachments/{attachmentId}", http.HandlerFunc(h.RemoveBidAttachment)).Methods("DELETE", "OPTIONS")
+		bids.Handle("/{tenderId}/reviews", http.HandlerFunc(h.GetBidReviews)).Methods("GET", "OPTIONS")
+	}
+
+	organizations := r.PathPrefix("/organizations").Subrouter()
+	{
+		organizations.Handle("/{organizationId}/api_keys", http.HandlerFunc(h.CreateAPIKey)).Methods("POST", "OPTIONS")
+		organizations.Handle("/{organizationId}/api_keys", http.HandlerFunc(h.GetAPIKeys)).Methods("GET", "OPTIONS")
+		organizations.Handle("/{organizationId}/api_keys/{keyId}", http.HandlerFunc(h.RevokeAPIKey)).Methods("DELETE", "OPTIONS")
+		organizations.Handle("/{organizationId}/members", http.HandlerFunc(h.GetMembers)).Methods("GET", "OPTIONS")
+		organizations.Handle("/{organizationId}/members/{username}", http.HandlerFunc(h.GrantRole)).Methods("PUT", "OPTIONS")
+		organizations.Handle("/{organizationId}/members/{username}", http.HandlerFunc(h.RevokeMember)).Methods("DELETE", "OPTIONS")
 	}
 }
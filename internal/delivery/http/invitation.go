@@ -0,0 +1,69 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) GetTenderInvitations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	invitations, err := h.uc.GetTenderInvitations(ctx, usecase.GetTenderInvitationsInput{
+		TenderID: tenderID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, invitations)
+}
+
+func (h *Handler) CreateInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, err := helper.ParseInvitationFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input.TenderID = tenderID
+	invitation, err := h.uc.CreateInvitation(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, invitation)
+}
+
+func (h *Handler) RemoveInvitation(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	invitationID, err := helper.ParseInvitationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	err = h.uc.RemoveInvitation(ctx, usecase.RemoveInvitationInput{
+		TenderID:     tenderID,
+		InvitationID: invitationID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, "ok")
+}
@@ -0,0 +1,125 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) GetTenderLots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lots, err := h.uc.GetTenderLots(ctx, usecase.GetTenderLotsInput{
+		TenderID: tenderID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, lots)
+}
+
+func (h *Handler) CreateLot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, err := helper.ParseLotFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input.TenderID = tenderID
+	lot, err := h.uc.CreateLot(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, lot)
+}
+
+func (h *Handler) UpdateLot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lotID, err := helper.ParseLotID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, err := helper.ParseUpdateLotFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input.TenderID = tenderID
+	input.LotID = lotID
+	lot, err := h.uc.UpdateLot(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, lot)
+}
+
+func (h *Handler) RollbackLot(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lotID, err := helper.ParseLotID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	version, err := helper.ParseVersion(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lot, err := h.uc.RollbackLot(ctx, usecase.RollbackLotInput{
+		TenderID: tenderID,
+		LotID:    lotID,
+		Version:  version,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, lot)
+}
+
+func (h *Handler) GetLotVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	lotID, err := helper.ParseLotID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	versions, err := h.uc.GetLotVersions(ctx, usecase.GetLotVersionsInput{
+		TenderID: tenderID,
+		LotID:    lotID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, versions)
+}
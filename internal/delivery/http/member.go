@@ -0,0 +1,62 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) GetMembers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	organizationID, err := helper.ParseOrganizationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	members, err := h.uc.GetMembers(ctx, usecase.GetMembersInput{
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, members)
+}
+
+func (h *Handler) GrantRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	organizationID, err := helper.ParseOrganizationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	member, err := h.uc.GrantRole(ctx, usecase.GrantRoleInput{
+		OrganizationID: organizationID,
+		Username:       helper.ParseMemberUsername(r),
+		Role:           helper.ParseRole(r),
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, member)
+}
+
+func (h *Handler) RevokeMember(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	organizationID, err := helper.ParseOrganizationID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	err = h.uc.RevokeMember(ctx, usecase.RevokeMemberInput{
+		OrganizationID: organizationID,
+		Username:       helper.ParseMemberUsername(r),
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, "ok")
+}
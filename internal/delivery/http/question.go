@@ -0,0 +1,80 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) AskQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, err := helper.ParseQuestionFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input.TenderID = tenderID
+	question, err := h.uc.AskQuestion(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, question)
+}
+
+func (h *Handler) GetTenderQuestions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	limit, offset, err := helper.ParseLimitOffset(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	questions, err := h.uc.GetTenderQuestions(ctx, usecase.GetTenderQuestionsInput{
+		TenderID: tenderID,
+		Limit:    limit,
+		Offset:   offset,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, questions)
+}
+
+func (h *Handler) AnswerQuestion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	questionID, err := helper.ParseQuestionID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input, err := helper.ParseAnswerFromBody(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	input.TenderID = tenderID
+	input.QuestionID = questionID
+	question, err := h.uc.AnswerQuestion(ctx, input)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, question)
+}
@@ -0,0 +1,65 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
+	"github.com/b0pof/avito-internship/internal/usecase"
+)
+
+func (h *Handler) SubmitFeedback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	bidID, err := helper.ParseBidID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	feedback, err := helper.ParseFeedback(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	bid, err := h.uc.SubmitFeedback(ctx, usecase.SubmitFeedbackInput{
+		BidID:    bidID,
+		Feedback: feedback,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.SetETag(w, strconv.Itoa(bid.Version))
+	helper.Respond(r.Context(), w, 200, bid)
+}
+
+func (h *Handler) GetBidReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	limit, offset, err := helper.ParseLimitOffset(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	authorUsername := helper.ParseAuthorUsername(r)
+	if authorUsername == "" {
+		helper.RespondError(w, r, validate.Field(model.ErrInvalidQueryParam, "authorUsername", "required"))
+		return
+	}
+	reviews, err := h.uc.GetBidReviews(ctx, usecase.GetBidReviewsInput{
+		TenderID:       tenderID,
+		AuthorUsername: authorUsername,
+		Limit:          limit,
+		Offset:         offset,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, reviews)
+}
@@ -3,10 +3,6 @@ package http
 import (
 	"net/http"
 
-	"github.com/pkg/errors"
-
-	"github.com/b0pof/avito-internship/internal/delivery/dto"
-	"github.com/b0pof/avito-internship/internal/model"
 	"github.com/b0pof/avito-internship/internal/pkg/helper"
 	"github.com/b0pof/avito-internship/internal/repository"
 	"github.com/b0pof/avito-internship/internal/usecase"
@@ -14,197 +10,243 @@ import (
 
 func (h *Handler) GetTenders(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	limit, offset, err := helper.ParseLimitOffset(r)
+	page, err := helper.ParsePagination(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	serviceTypes, err := helper.ParseServiseTypes(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	budgetMin, budgetMax, err := helper.ParseBudgetRange(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	budgetCurrency, err := helper.ParseBudgetCurrency(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	serviceTypes := helper.ParseServiseTypes(r)
 
 	result, err := h.uc.GetTenders(ctx, repository.GetTendersInput{
-		Limit:        limit,
-		Offset:       offset,
-		ServiceTypes: serviceTypes,
+		ServiceTypes:   serviceTypes,
+		BudgetMin:      budgetMin,
+		BudgetMax:      budgetMax,
+		BudgetCurrency: budgetCurrency,
+		Pagination:     page,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.RespondPage(w, r, result, page)
+}
+
+func (h *Handler) GetTender(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	tender, err := h.uc.GetTender(ctx, usecase.GetTenderInput{
+		TenderID: tenderID,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		case errors.Is(err, model.ErrInternal):
-			status = 500
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	helper.Respond(r.Context(), w, 200, result)
+	helper.SetETag(w, tender.Version)
+	helper.Respond(r.Context(), w, 200, tender)
 }
 
 func (h *Handler) CreateTender(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	tender, err := helper.ParseTenderFromBody(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
 	createdTender, err := h.uc.CreateTender(ctx, tender)
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrInternal):
-			status = 500
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, createdTender.Version)
 	helper.Respond(r.Context(), w, 200, createdTender)
 }
 
 func (h *Handler) GetMyTenders(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	limit, offset, err := helper.ParseLimitOffset(r)
+	page, err := helper.ParsePagination(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	username := helper.ParseUsername(r)
 	tenders, err := h.uc.GetMyTenders(ctx, usecase.GetMyTendersInput{
-		Limit:    limit,
-		Offset:   offset,
-		Username: username,
+		Pagination: page,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrInternal):
-			status = 500
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	helper.Respond(r.Context(), w, 200, tenders)
+	helper.RespondPage(w, r, tenders, page)
 }
 
 func (h *Handler) GetTenderStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenderID := helper.ParseTenderID(r)
-	username := helper.ParseUsername(r)
-	stat, err := h.uc.GetTenderStatus(ctx, usecase.GetTenderStatusInput{
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	tender, err := h.uc.GetTenderStatus(ctx, usecase.GetTenderStatusInput{
 		TenderID: tenderID,
-		Username: username,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
-	helper.Respond(r.Context(), w, 200, stat)
+	helper.SetETag(w, tender.Version)
+	helper.Respond(r.Context(), w, 200, tender.Status)
 }
 
 func (h *Handler) UpdateTenderStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenderID := helper.ParseTenderID(r)
-	username := helper.ParseUsername(r)
-	st := helper.ParseStatus(r)
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	st, err := helper.ParseTenderStatus(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	updTender, err := h.uc.UpdateTenderStatus(ctx, usecase.UpdateTenderStatusInput{
-		TenderID: tenderID,
-		Username: username,
-		Status:   st,
+		TenderID:         tenderID,
+		Status:           st,
+		ExpectedVersions: expectedVersions,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrInvalidAttributeValue):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, updTender.Version)
 	helper.Respond(r.Context(), w, 200, updTender)
 }
 
 func (h *Handler) UpdateTender(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenderID := helper.ParseTenderID(r)
-	username := helper.ParseUsername(r)
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	info, err := helper.ParseUpdateTenderInfo(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
 		return
 	}
 	updTender, err := h.uc.UpdateTender(ctx, usecase.UpdateTenderInput{
-		TenderID:    tenderID,
-		Username:    username,
-		Name:        info.Name,
-		Description: info.Description,
-		ServiceType: info.ServiceType,
+		TenderID:           tenderID,
+		Name:               info.Name,
+		Description:        info.Description,
+		ServiceType:        info.ServiceType,
+		SubmissionDeadline: info.SubmissionDeadline,
+		Budget:             info.Budget,
+		BudgetCurrency:     info.BudgetCurrency,
+		EnforceBudget:      info.EnforceBudget,
+		ExpectedVersions:   expectedVersions,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrInvalidAttributeValue):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrTenderNotFound):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, updTender.Version)
 	helper.Respond(r.Context(), w, 200, updTender)
 }
 
 func (h *Handler) RollbackTender(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tenderID := helper.ParseTenderID(r)
-	username := helper.ParseUsername(r)
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
 	version, err := helper.ParseVersion(r)
 	if err != nil {
-		helper.Respond(ctx, w, 400, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
+		return
+	}
+	expectedVersions, err := helper.ParseIfMatch(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
 		return
 	}
 	updTender, err := h.uc.RollbackTender(ctx, usecase.RollbackTenderInput{
-		TenderID: tenderID,
-		Username: username,
-		Version:  version,
+		TenderID:         tenderID,
+		Version:          version,
+		ExpectedVersions: expectedVersions,
 	})
 	if err != nil {
-		var status = 500
-		switch {
-		case errors.Is(err, model.ErrInvalidAttributeValue):
-			status = 400
-		case errors.Is(err, model.ErrUserNotFound):
-			status = 401
-		case errors.Is(err, model.ErrNoRights):
-			status = 403
-		case errors.Is(err, model.ErrTenderNotFound) || errors.Is(err, model.ErrNoSuchVersion):
-			status = 404
-		}
-		helper.Respond(ctx, w, status, dto.NewErrResponse(err))
+		helper.RespondError(w, r, err)
 		return
 	}
+	helper.SetETag(w, updTender.Version)
 	helper.Respond(r.Context(), w, 200, updTender)
 }
+
+func (h *Handler) GetTenderVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	versions, err := h.uc.GetTenderVersions(ctx, usecase.GetTenderVersionsInput{
+		TenderID: tenderID,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, versions)
+}
+
+func (h *Handler) GetTenderDiff(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	tenderID, err := helper.ParseTenderID(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	from, to, err := helper.ParseDiffVersions(r)
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	diff, err := h.uc.GetTenderDiff(ctx, usecase.GetTenderDiffInput{
+		TenderID: tenderID,
+		From:     from,
+		To:       to,
+	})
+	if err != nil {
+		helper.RespondError(w, r, err)
+		return
+	}
+	helper.Respond(r.Context(), w, 200, diff)
+}
@@ -1,34 +1,131 @@
 package model
 
-import "github.com/pkg/errors"
+import "strings"
+
+// Error is a domain error. Code is stable and meant for clients to switch on,
+// Status is the HTTP status the error is reported with.
+type Error struct {
+	Code    string
+	Status  int
+	Message string
+	Details []FieldError
+	// Context explains the error, the outermost text first.
+	Context []Text
+}
+
+// FieldError describes why the value of a request field is rejected. Reason
+// is Text in the default language.
+type FieldError struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+	Text   Text   `json:"-"`
+}
+
+func NewFieldError(field, key string, params ...any) FieldError {
+	text := NewText(key, params...)
+	return FieldError{
+		Field:  field,
+		Reason: text.Localize(DefaultLanguage),
+		Text:   text,
+	}
+}
+
+func newError(status int, code, message string) *Error {
+	return &Error{
+		Code:    code,
+		Status:  status,
+		Message: message,
+	}
+}
+
+func (e *Error) Error() string {
+	return e.Localize(DefaultLanguage)
+}
+
+func (e *Error) format(lang Language, msg string) string {
+	parts := make([]string, 0, len(e.Context)+2)
+	for _, c := range e.Context {
+		parts = append(parts, c.Localize(lang))
+	}
+	parts = append(parts, msg)
+	if len(e.Details) > 0 {
+		reasons := make([]string, 0, len(e.Details))
+		for _, d := range e.LocalizeDetails(lang) {
+			reasons = append(reasons, d.Field+": "+d.Reason)
+		}
+		parts = append(parts, strings.Join(reasons, "; "))
+	}
+	return strings.Join(parts, ": ")
+}
+
+// Is matches errors by code, so that an error with details still matches its sentinel.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of the error explained by the catalog text key.
+func (e *Error) Wrap(key string, params ...any) *Error {
+	c := *e
+	c.Context = append([]Text{NewText(key, params...)}, e.Context...)
+	return &c
+}
+
+// WithDetails returns a copy of the error listing the rejected fields.
+func (e *Error) WithDetails(details ...FieldError) *Error {
+	c := *e
+	c.Details = append(append([]FieldError(nil), e.Details...), details...)
+	return &c
+}
 
 var (
-	ErrInvalidQueryParam = errors.New("невалидное значение query-параметра")
-	ErrInvalidBody       = errors.New("невалидное тело запроса")
-	ErrInvalidPathParam  = errors.New("невалидное значение path-параметра")
+	ErrInvalidQueryParam = newError(400, "invalid_query_param", "невалидное значение query-параметра")
+	ErrInvalidBody       = newError(400, "invalid_body", "невалидное тело запроса")
+	ErrInvalidPathParam  = newError(400, "invalid_path_param", "невалидное значение path-параметра")
+	ErrInvalidHeader     = newError(400, "invalid_header", "невалидное значение заголовка")
 )
 
 var (
-	ErrNoOrganizationFound = errors.New("пользователь не является ответственным ни в одной организации")
+	ErrNoOrganizationFound = newError(403, "no_organization", "пользователь не является ответственным ни в одной организации")
+	ErrMemberNotFound      = newError(404, "member_not_found", "пользователь не состоит в организации")
+	ErrInvalidRole         = newError(400, "invalid_role", "невалидное значение роли")
 )
 
 var (
-	ErrUserNotFound = errors.New("пользователь не найден")
+	ErrUserNotFound       = newError(401, "user_not_found", "пользователь не найден")
+	ErrInvalidCredentials = newError(401, "invalid_credentials", "неверное имя пользователя или пароль")
+	ErrInvalidToken       = newError(401, "invalid_token", "невалидный токен доступа")
+	ErrInvalidAPIKey      = newError(401, "invalid_api_key", "невалидный API-ключ")
+	ErrAPIKeyNotFound     = newError(404, "api_key_not_found", "API-ключ не найден")
 )
 
 var (
-	ErrNoRights      = errors.New("доступ ограничен")
-	ErrWrongDecision = errors.New("неверное значение решения")
+	ErrNoRights      = newError(403, "no_rights", "доступ ограничен")
+	ErrWrongDecision = newError(400, "wrong_decision", "неверное значение решения")
 )
 
 var (
-	ErrTenderNotFound        = errors.New("тендер не найден")
-	ErrInvalidAttributeValue = errors.New("невалидное значение атрибута")
-	ErrNoSuchVersion         = errors.New("версия не найдена")
+	ErrTenderNotFound        = newError(404, "tender_not_found", "тендер не найден")
+	ErrInvalidAttributeValue = newError(400, "invalid_attribute_value", "невалидное значение атрибута")
+	ErrNoSuchVersion         = newError(404, "version_not_found", "версия не найдена")
+	ErrVersionMismatch       = newError(412, "version_mismatch", "версия объекта изменилась")
+	ErrIllegalTransition     = newError(409, "illegal_transition", "недопустимый переход статуса")
+	ErrSubmissionClosed      = newError(409, "submission_closed", "приём предложений завершён")
+	ErrBidsSealed            = newError(403, "bids_sealed", "предложения скрыты до окончания приёма")
+	ErrCriteriaLocked        = newError(409, "criteria_locked", "критерии нельзя менять после выставления оценок")
+	ErrNoCriteria            = newError(404, "no_criteria", "у тендера нет критериев оценки")
+	ErrLotNotFound           = newError(404, "lot_not_found", "лот не найден")
+	ErrAttachmentNotFound    = newError(404, "attachment_not_found", "вложение не найдено")
+	ErrAttachmentTooLarge    = newError(413, "attachment_too_large", "вложение слишком большое")
+	ErrAttachmentType        = newError(415, "attachment_type", "недопустимый тип вложения")
+	ErrQuestionNotFound      = newError(404, "question_not_found", "вопрос не найден")
+	ErrInvitationNotFound    = newError(404, "invitation_not_found", "приглашение не найдено")
+	ErrAuctionStep           = newError(409, "auction_step", "цена должна быть ниже лучшей хотя бы на шаг аукциона")
+	ErrBidDecided            = newError(409, "bid_decided", "победитель уже выбран, решения больше не принимаются")
 )
 
 var (
-	ErrNoBidsFound = errors.New("предложений не найдено")
-	ErrNoBidFound  = errors.New("предложение не найдено")
-	ErrInternal    = errors.New("внутренняя ошибка")
+	ErrNoBidsFound = newError(404, "bids_not_found", "предложений не найдено")
+	ErrNoBidFound  = newError(404, "bid_not_found", "предложение не найдено")
+	ErrInternal    = newError(500, "internal", "внутренняя ошибка")
 )
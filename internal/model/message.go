@@ -0,0 +1,80 @@
+package model
+
+// Language is a language error messages are reported in.
+type Language string
+
+const (
+	LanguageRU Language = "ru"
+	LanguageEN Language = "en"
+)
+
+// DefaultLanguage is the language of Error.Message.
+const DefaultLanguage = LanguageRU
+
+// _messages translates error messages by error code.
+var _messages = map[Language]map[string]string{
+	LanguageEN: {
+		"invalid_query_param":     "invalid query parameter value",
+		"invalid_body":            "invalid request body",
+		"invalid_path_param":      "invalid path parameter value",
+		"invalid_header":          "invalid header value",
+		"no_organization":         "user is not responsible for any organization",
+		"member_not_found":        "user is not a member of the organization",
+		"invalid_role":            "invalid role value",
+		"user_not_found":          "user not found",
+		"invalid_credentials":     "invalid username or password",
+		"invalid_token":           "invalid access token",
+		"invalid_api_key":         "invalid API key",
+		"api_key_not_found":       "API key not found",
+		"no_rights":               "access denied",
+		"wrong_decision":          "invalid decision value",
+		"tender_not_found":        "tender not found",
+		"invalid_attribute_value": "invalid attribute value",
+		"version_not_found":       "version not found",
+		"version_mismatch":        "object version has changed",
+		"illegal_transition":      "illegal status transition",
+		"submission_closed":       "submissions are closed",
+		"bids_sealed":             "bids are sealed until submissions close",
+		"criteria_locked":         "criteria cannot be changed once bids are scored",
+		"no_criteria":             "tender has no evaluation criteria",
+		"lot_not_found":           "lot not found",
+		"attachment_not_found":    "attachment not found",
+		"attachment_too_large":    "attachment is too large",
+		"attachment_type":         "attachment type is not allowed",
+		"question_not_found":      "question not found",
+		"invitation_not_found":    "invitation not found",
+		"auction_step":            "price must undercut the best price by at least the auction step",
+		"bid_decided":             "the winner is already chosen, decisions are no longer accepted",
+		"bids_not_found":          "no bids found",
+		"bid_not_found":           "bid not found",
+		"internal":                "internal error",
+	},
+}
+
+// SupportsLanguage reports whether error messages can be reported in lang.
+func SupportsLanguage(lang Language) bool {
+	_, ok := _messages[lang]
+	return lang == DefaultLanguage || ok
+}
+
+// Localize returns the error message in lang, with its context and the
+// rejected fields. Errors without a translation fall back to the default message.
+func (e *Error) Localize(lang Language) string {
+	msg, ok := _messages[lang][e.Code]
+	if !ok {
+		msg = e.Message
+	}
+	return e.format(lang, msg)
+}
+
+// LocalizeDetails returns the rejected fields with their reasons in lang.
+func (e *Error) LocalizeDetails(lang Language) []FieldError {
+	details := make([]FieldError, 0, len(e.Details))
+	for _, d := range e.Details {
+		if d.Text.Key != "" {
+			d.Reason = d.Text.Localize(lang)
+		}
+		details = append(details, d)
+	}
+	return details
+}
@@ -0,0 +1,46 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextsTranslated(t *testing.T) {
+	for key := range _texts[DefaultLanguage] {
+		if _, ok := _texts[LanguageEN][key]; !ok {
+			t.Errorf("text %q has no English translation", key)
+		}
+	}
+	for key := range _texts[LanguageEN] {
+		if _, ok := _texts[DefaultLanguage][key]; !ok {
+			t.Errorf("English text %q is missing from the default catalog", key)
+		}
+	}
+}
+
+func TestErrorLocalize(t *testing.T) {
+	err := ErrInvalidBody.
+		WithDetails(NewFieldError("name", "max_length", 100), NewFieldError("tenderId", "uuid")).
+		Wrap("tender_closed")
+
+	want := "тендер закрыт: невалидное тело запроса: name: длина не должна превышать 100 символов; tenderId: ожидается UUID"
+	if got := err.Error(); got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	want = "the tender is closed: invalid request body: name: must be at most 100 characters long; tenderId: UUID expected"
+	if got := err.Localize(LanguageEN); got != want {
+		t.Errorf("Localize(en) = %q, want %q", got, want)
+	}
+	for _, d := range err.LocalizeDetails(LanguageEN) {
+		if strings.ContainsFunc(d.Reason, isCyrillic) {
+			t.Errorf("reason of %s is not translated: %q", d.Field, d.Reason)
+		}
+	}
+	if !ErrInvalidBody.Is(err) || !err.Is(ErrInvalidBody) {
+		t.Error("wrapped error does not match its sentinel")
+	}
+}
+
+func isCyrillic(r rune) bool {
+	return r >= 'А' && r <= 'я' || r == 'ё' || r == 'Ё'
+}
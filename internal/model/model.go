@@ -1,6 +1,47 @@
 package model
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	TenderStatusCreated   = "Created"
+	TenderStatusPublished = "Published"
+	TenderStatusClosed    = "Closed"
+)
+
+const (
+	BidStatusCreated   = "Created"
+	BidStatusPublished = "Published"
+	BidStatusCanceled  = "Canceled"
+)
+
+const (
+	LotStatusOpen    = "Open"
+	LotStatusAwarded = "Awarded"
+)
+
+// Amount is an exact decimal sum of money. It is read from JSON numbers or
+// strings and written as a string, so that no precision is lost to floats.
+type Amount string
+
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*a = Amount(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(data, &n); err != nil {
+		return err
+	}
+	*a = Amount(n)
+	return nil
+}
 
 type Bid struct {
 	ID         string    `db:"id" json:"id"`
@@ -8,16 +49,255 @@ type Bid struct {
 	Status     string    `db:"status" json:"status"`
 	AuthorType string    `db:"author_type" json:"authorType"`
 	AuthorID   string    `db:"author_id" json:"authorId"`
+	LotID      *string   `db:"lot_id" json:"lotId,omitempty"`
+	Price      *Amount   `db:"price" json:"price,omitempty"`
+	Currency   *string   `db:"currency" json:"currency,omitempty"`
 	Version    int       `db:"version" json:"version"`
 	CreatedAt  time.Time `db:"created_at" json:"createdAt"`
 }
 
 type Tender struct {
+	ID                 string     `db:"id" json:"id"`
+	Name               string     `db:"name" json:"name"`
+	Description        string     `db:"description" json:"description"`
+	Status             string     `db:"status" json:"status"`
+	ServiceType        string     `db:"service_type" json:"serviceType"`
+	SubmissionDeadline *time.Time `db:"submission_deadline" json:"submissionDeadline,omitempty"`
+	Budget             *Amount    `db:"budget" json:"budget,omitempty"`
+	BudgetCurrency     *string    `db:"budget_currency" json:"budgetCurrency,omitempty"`
+	EnforceBudget      bool       `db:"enforce_budget" json:"enforceBudget"`
+	Sealed             bool       `db:"sealed" json:"sealed"`
+	Private            bool       `db:"private" json:"private"`
+	Auction            bool       `db:"auction" json:"auction"`
+	AuctionStart       *time.Time `db:"auction_start" json:"auctionStart,omitempty"`
+	AuctionStep        *Amount    `db:"auction_step" json:"auctionStep,omitempty"`
+	AuctionExtension   int        `db:"auction_extension" json:"auctionExtension,omitempty"`
+	Version            string     `db:"version" json:"version"`
+	CreatedAt          time.Time  `db:"created_at" json:"createdAt"`
+}
+
+// SubmissionClosed reports whether the tender deadline has passed by now.
+func (t Tender) SubmissionClosed(now time.Time) bool {
+	return t.SubmissionDeadline != nil && !now.Before(*t.SubmissionDeadline)
+}
+
+// BidsSealed reports whether bid contents must still be hidden from the
+// tender organization: sealed tenders reveal bids once closed or past deadline.
+func (t Tender) BidsSealed(now time.Time) bool {
+	return t.Sealed && t.Status != TenderStatusClosed && !t.SubmissionClosed(now)
+}
+
+// Lot is a part of a tender awarded to its own winner.
+type Lot struct {
 	ID          string    `db:"id" json:"id"`
+	TenderID    string    `db:"tender_id" json:"tenderId"`
 	Name        string    `db:"name" json:"name"`
 	Description string    `db:"description" json:"description"`
+	ServiceType string    `db:"service_type" json:"serviceType"`
 	Status      string    `db:"status" json:"status"`
+	WinnerBidID *string   `db:"winner_bid_id" json:"winnerBidId,omitempty"`
+	Version     int       `db:"version" json:"version"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+type Attachment struct {
+	ID          string    `db:"id" json:"id"`
+	Filename    string    `db:"filename" json:"filename"`
+	ContentType string    `db:"content_type" json:"contentType"`
+	Size        int64     `db:"size" json:"size"`
+	Checksum    string    `db:"checksum" json:"sha256"`
+	StorageKey  string    `db:"storage_key" json:"-"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Invitation admits an organization or a single employee to a private tender.
+type Invitation struct {
+	ID             string    `db:"id" json:"id"`
+	OrganizationID *string   `db:"organization_id" json:"organizationId,omitempty"`
+	Username       *string   `db:"username" json:"username,omitempty"`
+	CreatedAt      time.Time `db:"created_at" json:"createdAt"`
+}
+
+// Question is a clarification asked on a tender. Public answers are shown to
+// every bidder, the rest only to the asker and the organization.
+type Question struct {
+	ID         string     `db:"id" json:"id"`
+	Question   string     `db:"question" json:"question"`
+	Answer     *string    `db:"answer" json:"answer,omitempty"`
+	Public     bool       `db:"public" json:"public"`
+	Mine       bool       `db:"mine" json:"mine"`
+	CreatedAt  time.Time  `db:"created_at" json:"createdAt"`
+	AnsweredAt *time.Time `db:"answered_at" json:"answeredAt,omitempty"`
+}
+
+// AuctionStarted reports whether bid prices of an auction tender may only go
+// down by now. Auctions without a start run from the publication.
+func (t Tender) AuctionStarted(now time.Time) bool {
+	return t.Auction && (t.AuctionStart == nil || !now.Before(*t.AuctionStart))
+}
+
+// Auction is the state of a reverse auction shown to bidders.
+type Auction struct {
+	BestPrice *Amount    `db:"best_price" json:"bestPrice,omitempty"`
+	Currency  string     `db:"currency" json:"currency"`
+	Step      Amount     `db:"step" json:"step"`
+	Bids      int        `db:"bids" json:"bids"`
+	StartsAt  *time.Time `db:"starts_at" json:"startsAt,omitempty"`
+	EndsAt    time.Time  `db:"ends_at" json:"endsAt"`
+}
+
+// TenderBids is a page of tender bids. While bids are sealed only their
+// count is disclosed.
+type TenderBids struct {
+	Page[Bid]
+	Sealed bool
+	Count  int
+}
+
+type DecisionTally struct {
+	Approved int `db:"approved" json:"approved"`
+	Rejected int `db:"rejected" json:"rejected"`
+	Quorum   int `db:"quorum" json:"quorum"`
+}
+
+// Reached reports whether the bid is approved by the quorum and rejected by no one.
+func (t DecisionTally) Reached() bool {
+	return t.Rejected == 0 && t.Approved >= t.Quorum
+}
+
+type BidDecisionResult struct {
+	Bid
+	Decisions DecisionTally `json:"decisions"`
+}
+
+type BidReview struct {
+	ID          string    `db:"id" json:"id"`
+	Description string    `db:"description" json:"description"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+type Criterion struct {
+	ID     string `db:"id" json:"id"`
+	Name   string `db:"name" json:"name" validate:"required,max=100"`
+	Weight int    `db:"weight" json:"weight"`
+}
+
+type BidScore struct {
+	CriterionID string `db:"criterion_id" json:"criterionId"`
+	Score       int    `db:"score" json:"score"`
+}
+
+// CriterionScore is the average score of a bid on one criterion across all scorers.
+type CriterionScore struct {
+	BidID       string  `db:"bid_id" json:"-"`
+	CriterionID string  `db:"criterion_id" json:"criterionId"`
+	Average     float64 `db:"average" json:"average"`
+	Scorers     int     `db:"scorers" json:"scorers"`
+}
+
+type BidRanking struct {
+	Rank   int              `json:"rank"`
+	Bid    Bid              `json:"bid"`
+	Total  float64          `json:"total"`
+	Scores []CriterionScore `json:"scores"`
+}
+
+type TenderVersion struct {
+	Version            int        `db:"version" json:"version"`
+	Name               string     `db:"name" json:"name"`
+	Description        string     `db:"description" json:"description"`
+	ServiceType        string     `db:"service_type" json:"serviceType"`
+	SubmissionDeadline *time.Time `db:"submission_deadline" json:"submissionDeadline,omitempty"`
+	Budget             *Amount    `db:"budget" json:"budget,omitempty"`
+	BudgetCurrency     *string    `db:"budget_currency" json:"budgetCurrency,omitempty"`
+	EnforceBudget      bool       `db:"enforce_budget" json:"enforceBudget"`
+	CreatedAt          time.Time  `db:"created_at" json:"createdAt"`
+}
+
+type LotVersion struct {
+	Version     int       `db:"version" json:"version"`
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
 	ServiceType string    `db:"service_type" json:"serviceType"`
-	Version     string    `db:"version" json:"version"`
 	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
 }
+
+type BidVersion struct {
+	Version     int       `db:"version" json:"version"`
+	Name        string    `db:"name" json:"name"`
+	Description string    `db:"description" json:"description"`
+	Price       *Amount   `db:"price" json:"price,omitempty"`
+	Currency    *string   `db:"currency" json:"currency,omitempty"`
+	CreatedAt   time.Time `db:"created_at" json:"createdAt"`
+}
+
+type FieldDiff struct {
+	Field   string `json:"field"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Unified string `json:"unified,omitempty"`
+}
+
+type VersionDiff struct {
+	From    int         `json:"from"`
+	To      int         `json:"to"`
+	Changes []FieldDiff `json:"changes"`
+}
+
+type Employee struct {
+	ID       string `db:"id" json:"id"`
+	Username string `db:"username" json:"username"`
+}
+
+// Principal is the authenticated caller: either an employee or an organization API key.
+type Principal struct {
+	UserID         string
+	Username       string
+	OrganizationID string
+	APIKeyID       string
+	// KeyCreatorID is the employee who issued the API key. It only attributes
+	// changes made with the key and grants no personal rights.
+	KeyCreatorID string
+}
+
+func (p Principal) IsAPIKey() bool {
+	return p.APIKeyID != ""
+}
+
+type AuthToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type APIKey struct {
+	ID         string     `db:"id" json:"id"`
+	Name       string     `db:"name" json:"name"`
+	Prefix     string     `db:"key_prefix" json:"prefix"`
+	CreatedAt  time.Time  `db:"created_at" json:"createdAt"`
+	LastUsedAt *time.Time `db:"last_used_at" json:"lastUsedAt"`
+	RevokedAt  *time.Time `db:"revoked_at" json:"revokedAt"`
+}
+
+type IssuedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type OrganizationMember struct {
+	UserID   string `db:"user_id" json:"userId"`
+	Username string `db:"username" json:"username"`
+	Role     string `db:"role" json:"role"`
+}
+
+type AuditEvent struct {
+	ID         string          `json:"id"`
+	EntityType string          `json:"entityType"`
+	EntityID   string          `json:"entityId"`
+	Action     string          `json:"action"`
+	ActorID    *string         `json:"actorId"`
+	APIKeyID   *string         `json:"apiKeyId,omitempty"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	RequestID  *string         `json:"requestId,omitempty"`
+	CreatedAt  time.Time       `json:"createdAt"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import "encoding/json"
+
+// Optional is a field of a partial update. It tells a field omitted from the
+// body, which keeps the current value, from an explicit null, which clears it.
+type Optional[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	o.Set = true
+	if string(data) == "null" {
+		o.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &o.Value)
+}
+
+// Ptr returns the value, or nil if the field is omitted or null.
+func (o Optional[T]) Ptr() *T {
+	if !o.Set || o.Null {
+		return nil
+	}
+	return &o.Value
+}
+
+// Cleared reports whether the field is explicitly set to null.
+func (o Optional[T]) Cleared() bool {
+	return o.Set && o.Null
+}
+
+// Present returns the value and whether the field is set to a non-null value.
+func (o Optional[T]) Present() (any, bool) {
+	return o.Value, o.Set && !o.Null
+}
@@ -0,0 +1,73 @@
+package model
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type patch struct {
+	Name     Optional[string]    `json:"name"`
+	Deadline Optional[time.Time] `json:"deadline"`
+	Enforce  Optional[bool]      `json:"enforce"`
+}
+
+func TestOptionalUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		set     bool
+		null    bool
+		value   string
+		cleared bool
+	}{
+		{name: "omitted", body: `{}`},
+		{name: "null", body: `{"name": null}`, set: true, null: true, cleared: true},
+		{name: "empty", body: `{"name": ""}`, set: true},
+		{name: "value", body: `{"name": "Доставка"}`, set: true, value: "Доставка"},
+		{name: "quotes", body: `{"name": "O'Brien \"Ltd\""}`, set: true, value: `O'Brien "Ltd"`},
+		{name: "sql", body: `{"name": "O'Brien'; DROP TABLE tender;--"}`, set: true, value: "O'Brien'; DROP TABLE tender;--"},
+		{name: "unicode", body: `{"name": "Тендер «Сибирь» 🚧 \u0000"}`, set: true, value: "Тендер «Сибирь» 🚧 \x00"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p patch
+			if err := json.Unmarshal([]byte(tt.body), &p); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if p.Name.Set != tt.set || p.Name.Null != tt.null || p.Name.Value != tt.value {
+				t.Errorf("got %+v, want set=%v null=%v value=%q", p.Name, tt.set, tt.null, tt.value)
+			}
+			if p.Name.Cleared() != tt.cleared {
+				t.Errorf("Cleared() = %v, want %v", p.Name.Cleared(), tt.cleared)
+			}
+			ptr := p.Name.Ptr()
+			if (ptr != nil) != (tt.set && !tt.null) {
+				t.Fatalf("Ptr() = %v for set=%v null=%v", ptr, tt.set, tt.null)
+			}
+			if ptr != nil && *ptr != tt.value {
+				t.Errorf("*Ptr() = %q, want %q", *ptr, tt.value)
+			}
+			if _, present := p.Name.Present(); present != (tt.set && !tt.null) {
+				t.Errorf("Present() = %v for set=%v null=%v", present, tt.set, tt.null)
+			}
+		})
+	}
+}
+
+func TestOptionalUnmarshalJSONTypes(t *testing.T) {
+	var p patch
+	if err := json.Unmarshal([]byte(`{"deadline": "2024-10-01T12:00:00Z", "enforce": false}`), &p); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if !p.Deadline.Set || !p.Deadline.Value.Equal(time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("deadline = %+v", p.Deadline)
+	}
+	// false is a value, not an omitted field
+	if !p.Enforce.Set || p.Enforce.Null || p.Enforce.Value {
+		t.Errorf("enforce = %+v", p.Enforce)
+	}
+	if err := json.Unmarshal([]byte(`{"enforce": "yes"}`), &p); err == nil {
+		t.Error("expected an error for a value of the wrong type")
+	}
+}
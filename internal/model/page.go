@@ -0,0 +1,15 @@
+package model
+
+// Cursor points at the last item of a page of a list ordered by name and ID.
+type Cursor struct {
+	Name string `json:"name"`
+	ID   string `json:"id"`
+}
+
+// Page is a page of a list. Next is set if the list is paged by cursor and
+// more items follow; Total is set if the size of the whole list was requested.
+type Page[T any] struct {
+	Items []T
+	Next  *Cursor
+	Total *int
+}
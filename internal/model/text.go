@@ -0,0 +1,184 @@
+package model
+
+import "fmt"
+
+// Text is a client-facing text given by its catalog key and the values put
+// into it, so that it is reported in the language the client negotiated.
+type Text struct {
+	Key    string
+	Params []any
+}
+
+func NewText(key string, params ...any) Text {
+	return Text{Key: key, Params: params}
+}
+
+// Localize returns the text in lang, falling back to the default language.
+func (t Text) Localize(lang Language) string {
+	format, ok := _texts[lang][t.Key]
+	if !ok {
+		format = _texts[DefaultLanguage][t.Key]
+	}
+	if len(t.Params) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, t.Params...)
+}
+
+// _texts holds the texts explaining errors and rejected fields by key.
+var _texts = map[Language]map[string]string{
+	LanguageRU: {
+		// field reasons
+		"required":       "обязательное поле",
+		"max_length":     "длина не должна превышать %d символов",
+		"min_length":     "длина должна быть не меньше %d символов",
+		"one_of":         "допустимые значения: %s",
+		"uuid":           "ожидается UUID",
+		"nul_character":  "символ NUL не допускается",
+		"unknown_field":  "неизвестное поле",
+		"int_range":      "ожидается целое число от %d до %d",
+		"non_negative":   "ожидается неотрицательное целое число",
+		"positive":       "ожидается положительное целое число",
+		"boolean":        "ожидается true или false",
+		"with_cursor":    "не используется вместе с cursor",
+		"cursor_limit":   "с cursor ожидается целое число от %d до %d",
+		"invalid_cursor": "невалидный курсор",
+
+		// error context
+		"transition":                "%s -> %s",
+		"authorization_required":    "требуется авторизация",
+		"api_key_not_personal":      "API-ключ не действует от имени сотрудника",
+		"api_key_manages_keys":      "API-ключ не может управлять ключами",
+		"api_key_manages_roles":     "API-ключ не может управлять ролями",
+		"last_admin":                "нельзя лишить организацию последнего администратора",
+		"employee_not_found":        "сотрудник не найден",
+		"organization_not_found":    "организация не найдена",
+		"field_not_null":            "поле не может быть null",
+		"tender_name_required":      "название тендера не может быть пустым",
+		"service_type_required":     "нужно указать тип услуг",
+		"bid_name_required":         "название предложения не может быть пустым",
+		"deadline_in_past":          "срок подачи предложений должен быть в будущем",
+		"enforced_budget_required":  "для контроля бюджета нужно указать бюджет",
+		"sealed_deadline_required":  "для закрытого приёма предложений нужен срок подачи",
+		"sealed_deadline":           "у закрытого приёма предложений и аукциона должен быть срок подачи",
+		"tender_not_published":      "тендер не опубликован",
+		"tender_closed":             "тендер закрыт",
+		"tender_invite_only":        "тендер доступен только по приглашению",
+		"organization_bid":          "невозможно создать предложение от имени организации",
+		"bid_rejected":              "предложение отклонено",
+		"author_has_no_bids":        "автор не создавал предложений для тендера",
+		"cursor_sort":               "с cursor поддерживается только сортировка по name",
+		"invalid_amount":            "невалидная сумма",
+		"invalid_currency":          "невалидный код валюты",
+		"price_pair":                "сумма и валюта указываются вместе",
+		"price_required":            "тендер требует указать цену",
+		"price_over_budget":         "цена превышает бюджет тендера",
+		"budget_currency_mismatch":  "валюта предложения не совпадает с валютой бюджета",
+		"criteria_count":            "количество критериев должно быть от 1 до 20",
+		"criterion_weight":          "вес критерия должен быть от 1 до 100",
+		"criterion_names_repeat":    "названия критериев повторяются",
+		"criterion_not_in_tender":   "критерий не относится к тендеру",
+		"criterion_scored_twice":    "критерий оценён дважды",
+		"scores_required":           "не переданы оценки",
+		"score_range":               "оценка должна быть от 0 до 10",
+		"employees_score":           "оценки выставляют сотрудники",
+		"published_bids_scored":     "оценивать можно только опубликованные предложения",
+		"lot_fields_required":       "у лота должны быть название, описание и тип услуг",
+		"lots_before_publishing":    "лоты добавляются до публикации тендера",
+		"lot_awarded":               "лот уже разыгран",
+		"lot_required":              "нужно указать лот",
+		"lot_not_in_tender":         "лот не относится к тендеру",
+		"tender_without_lots":       "тендер не разделён на лоты",
+		"invalid_file_name":         "невалидное имя файла",
+		"invitee_required":          "нужно указать либо организацию, либо сотрудника",
+		"invitations_private_only":  "приглашения нужны только приватным тендерам",
+		"invitation_exists":         "приглашение уже выдано",
+		"tender_not_auction":        "тендер проводится не в форме аукциона",
+		"auction_terms_only":        "условия аукциона задаются только для аукциона",
+		"auction_terms_required":    "для аукциона нужны срок подачи и начальная цена в бюджете",
+		"auction_start_price":       "у аукциона должна быть начальная цена",
+		"auction_starts_late":       "аукцион должен начинаться до срока подачи",
+		"auction_sealed":            "аукцион не может быть с закрытым приёмом предложений",
+		"auction_step":              "невалидный шаг аукциона",
+		"auction_extension":         "продление аукциона должно быть от 0 до 1440 минут",
+		"auction_price_required":    "в аукционе нужно указать цену",
+		"auction_currency_mismatch": "валюта предложения не совпадает с валютой аукциона",
+		"auction_rollback":          "во время аукциона откат предложения запрещён",
+	},
+	LanguageEN: {
+		"required":       "required field",
+		"max_length":     "must be at most %d characters long",
+		"min_length":     "must be at least %d characters long",
+		"one_of":         "allowed values: %s",
+		"uuid":           "UUID expected",
+		"nul_character":  "the NUL character is not allowed",
+		"unknown_field":  "unknown field",
+		"int_range":      "integer from %d to %d expected",
+		"non_negative":   "non-negative integer expected",
+		"positive":       "positive integer expected",
+		"boolean":        "true or false expected",
+		"with_cursor":    "not used together with cursor",
+		"cursor_limit":   "integer from %d to %d expected with cursor",
+		"invalid_cursor": "invalid cursor",
+
+		"transition":                "%s -> %s",
+		"authorization_required":    "authorization required",
+		"api_key_not_personal":      "an API key does not act on behalf of an employee",
+		"api_key_manages_keys":      "an API key cannot manage keys",
+		"api_key_manages_roles":     "an API key cannot manage roles",
+		"last_admin":                "the organization cannot lose its last administrator",
+		"employee_not_found":        "employee not found",
+		"organization_not_found":    "organization not found",
+		"field_not_null":            "the field cannot be null",
+		"tender_name_required":      "the tender name cannot be empty",
+		"service_type_required":     "a service type is required",
+		"bid_name_required":         "the bid name cannot be empty",
+		"deadline_in_past":          "the submission deadline must be in the future",
+		"enforced_budget_required":  "a budget is required to enforce it",
+		"sealed_deadline_required":  "sealed bids require a submission deadline",
+		"sealed_deadline":           "sealed bids and auctions require a submission deadline",
+		"tender_not_published":      "the tender is not published",
+		"tender_closed":             "the tender is closed",
+		"tender_invite_only":        "the tender is available by invitation only",
+		"organization_bid":          "cannot make a bid on behalf of the organization",
+		"bid_rejected":              "the bid is rejected",
+		"author_has_no_bids":        "the author has made no bids for the tender",
+		"cursor_sort":               "only sorting by name is supported with cursor",
+		"invalid_amount":            "invalid amount",
+		"invalid_currency":          "invalid currency code",
+		"price_pair":                "an amount and a currency are given together",
+		"price_required":            "the tender requires a price",
+		"price_over_budget":         "the price exceeds the tender budget",
+		"budget_currency_mismatch":  "the bid currency differs from the budget currency",
+		"criteria_count":            "there must be from 1 to 20 criteria",
+		"criterion_weight":          "a criterion weight must be from 1 to 100",
+		"criterion_names_repeat":    "criterion names repeat",
+		"criterion_not_in_tender":   "the criterion does not belong to the tender",
+		"criterion_scored_twice":    "the criterion is scored twice",
+		"scores_required":           "no scores given",
+		"score_range":               "a score must be from 0 to 10",
+		"employees_score":           "scores are given by employees",
+		"published_bids_scored":     "only published bids can be scored",
+		"lot_fields_required":       "a lot must have a name, a description and a service type",
+		"lots_before_publishing":    "lots are added before the tender is published",
+		"lot_awarded":               "the lot is already awarded",
+		"lot_required":              "a lot is required",
+		"lot_not_in_tender":         "the lot does not belong to the tender",
+		"tender_without_lots":       "the tender is not divided into lots",
+		"invalid_file_name":         "invalid file name",
+		"invitee_required":          "either an organization or an employee is required",
+		"invitations_private_only":  "only private tenders need invitations",
+		"invitation_exists":         "the invitation is already issued",
+		"tender_not_auction":        "the tender is not an auction",
+		"auction_terms_only":        "auction terms are set for auctions only",
+		"auction_terms_required":    "an auction requires a submission deadline and a starting price in the budget",
+		"auction_start_price":       "an auction must have a starting price",
+		"auction_starts_late":       "the auction must start before the submission deadline",
+		"auction_sealed":            "an auction cannot have sealed bids",
+		"auction_step":              "invalid auction step",
+		"auction_extension":         "an auction extension must be from 0 to 1440 minutes",
+		"auction_price_required":    "a price is required in an auction",
+		"auction_currency_mismatch": "the bid currency differs from the auction currency",
+		"auction_rollback":          "bids cannot be rolled back during an auction",
+	},
+}
@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+const (
+	_apiKeyPrefix   = "tnd_"
+	_apiKeyBytes    = 32
+	_apiKeyShownLen = 12
+)
+
+// GenerateAPIKey returns a new random API key and its displayable prefix.
+func GenerateAPIKey() (string, string, error) {
+	buf := make([]byte, _apiKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", model.ErrInternal
+	}
+	key := _apiKeyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return key, key[:_apiKeyShownLen], nil
+}
+
+// HashAPIKey returns hex-encoded SHA-256 of the key. Keys are random enough
+// for a fast hash, and only hashes are stored at rest.
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,20 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+type ctxPrincipal struct{}
+
+// WithPrincipal adds authenticated caller to context.
+func WithPrincipal(ctx context.Context, p model.Principal) context.Context {
+	return context.WithValue(ctx, ctxPrincipal{}, p)
+}
+
+// PrincipalFromContext returns authenticated caller from context.
+func PrincipalFromContext(ctx context.Context) (model.Principal, bool) {
+	p, ok := ctx.Value(ctxPrincipal{}).(model.Principal)
+	return p, ok
+}
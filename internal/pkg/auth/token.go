@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/b0pof/avito-internship/internal/config"
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+type claims struct {
+	Username string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+// TokenManager issues and verifies HMAC-signed JWT access tokens.
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewTokenManager(cfg config.Auth) *TokenManager {
+	return &TokenManager{
+		secret: []byte(cfg.Secret),
+		ttl:    cfg.TokenTTL,
+	}
+}
+
+func (m *TokenManager) Issue(user model.Employee) (model.AuthToken, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		Username: user.Username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.ID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	})
+	signed, err := token.SignedString(m.secret)
+	if err != nil {
+		return model.AuthToken{}, model.ErrInternal
+	}
+	return model.AuthToken{
+		Token:     signed,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (m *TokenManager) Parse(token string) (model.Employee, error) {
+	var c claims
+	_, err := jwt.ParseWithClaims(token, &c, func(_ *jwt.Token) (interface{}, error) {
+		return m.secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil || c.Subject == "" {
+		return model.Employee{}, model.ErrInvalidToken
+	}
+	return model.Employee{
+		ID:       c.Subject,
+		Username: c.Username,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package diff
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// _context is the number of unchanged lines printed around each change.
+const _context = 3
+
+type edit struct {
+	op   byte
+	text string
+	aIdx int
+	bIdx int
+}
+
+// Unified returns a line-based unified diff between a and b.
+// An empty string is returned when texts are equal.
+func Unified(fromName, toName, a, b string) string {
+	if a == b {
+		return ""
+	}
+	edits := lineEdits(splitLines(a), splitLines(b))
+
+	var sb strings.Builder
+	sb.WriteString("--- " + fromName + "\n")
+	sb.WriteString("+++ " + toName + "\n")
+
+	k := 0
+	for k < len(edits) {
+		for k < len(edits) && edits[k].op == ' ' {
+			k++
+		}
+		if k == len(edits) {
+			break
+		}
+		start := max(0, k-_context)
+		end := k
+		for end < len(edits) {
+			if edits[end].op != ' ' {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].op == ' ' {
+				run++
+			}
+			if run == len(edits) || run-end > 2*_context {
+				end = min(len(edits), end+_context)
+				break
+			}
+			end = run
+		}
+		writeHunk(&sb, edits[start:end])
+		k = end
+	}
+	return sb.String()
+}
+
+func writeHunk(sb *strings.Builder, hunk []edit) {
+	var aLen, bLen int
+	for _, e := range hunk {
+		if e.op != '+' {
+			aLen++
+		}
+		if e.op != '-' {
+			bLen++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(hunk[0].aIdx, aLen), hunkRange(hunk[0].bIdx, bLen))
+	for _, e := range hunk {
+		sb.WriteByte(e.op)
+		sb.WriteString(e.text)
+		sb.WriteByte('\n')
+	}
+}
+
+func hunkRange(start, length int) string {
+	switch length {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return strconv.Itoa(start + 1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, length)
+	}
+}
+
+// lineEdits builds the shortest edit script between a and b from their longest common subsequence.
+func lineEdits(a, b []string) []edit {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	edits := make([]edit, 0, n+m)
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			edits = append(edits, edit{op: ' ', text: a[i], aIdx: i, bIdx: j})
+			i++
+			j++
+		case i < n && (j == m || lcs[i+1][j] >= lcs[i][j+1]):
+			edits = append(edits, edit{op: '-', text: a[i], aIdx: i, bIdx: j})
+			i++
+		default:
+			edits = append(edits, edit{op: '+', text: b[j], aIdx: i, bIdx: j})
+			j++
+		}
+	}
+	return edits
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
@@ -0,0 +1,63 @@
+package helper
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+const _attachmentField = "file"
+
+// ParseAttachmentUpload returns the file part of a multipart/form-data body
+// without buffering it, so the upload is streamed to the storage.
+func ParseAttachmentUpload(r *http.Request) (*multipart.Part, error) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, model.ErrInvalidBody
+	}
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			return nil, model.ErrInvalidBody
+		}
+		if part.FormName() == _attachmentField {
+			return part, nil
+		}
+	}
+}
+
+func ParseAttachmentID(r *http.Request) (string, error) {
+	return parseIDVar(r, "attachmentId")
+}
+
+// ParseAttachmentsVersion returns the optional version query parameter, zero if absent.
+func ParseAttachmentsVersion(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("version")
+	if value == "" {
+		return 0, nil
+	}
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, validate.Field(model.ErrInvalidQueryParam, "version", "positive")
+	}
+	return version, nil
+}
+
+// RespondFile streams the attachment contents with its metadata in headers.
+func RespondFile(ctx context.Context, w http.ResponseWriter, a model.Attachment, body io.Reader) {
+	w.Header().Set("Content-Type", a.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(a.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+	w.Header().Set("X-Checksum-SHA256", a.Checksum)
+	w.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(w, body); err != nil {
+		logger.Error(ctx, "attachment write error: "+err.Error())
+	}
+}
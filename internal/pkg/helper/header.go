@@ -0,0 +1,66 @@
+package helper
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+// SetETag sets ETag derived from the tender or bid version. Must be called before Respond.
+func SetETag(w http.ResponseWriter, version string) {
+	w.Header().Set("ETag", strconv.Quote(version))
+}
+
+// ParseIfMatch returns the versions listed in the If-Match header, any of
+// which the change is allowed for. None are returned when the header is
+// absent or equals "*". If-Match uses the strong comparison, so weak tags
+// never match, and a header with weak tags only fails with ErrVersionMismatch.
+func ParseIfMatch(r *http.Request) ([]int, error) {
+	value := strings.TrimSpace(r.Header.Get("If-Match"))
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+	var versions []int
+	for _, tag := range strings.Split(value, ",") {
+		tag, weak := strings.CutPrefix(strings.TrimSpace(tag), "W/")
+		unquoted, err := strconv.Unquote(tag)
+		if err != nil {
+			return nil, model.ErrInvalidHeader
+		}
+		version, err := strconv.Atoi(unquoted)
+		if err != nil || version < 1 {
+			return nil, model.ErrInvalidHeader
+		}
+		if !weak {
+			versions = append(versions, version)
+		}
+	}
+	if len(versions) == 0 {
+		return nil, model.ErrVersionMismatch
+	}
+	return versions, nil
+}
+
+// ParseLanguage picks the language of error messages from the Accept-Language
+// header by quality, falling back to model.DefaultLanguage.
+func ParseLanguage(r *http.Request) model.Language {
+	lang, best := model.DefaultLanguage, 0.0
+	for _, item := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(item), ";")
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q > best && model.SupportsLanguage(model.Language(base)) {
+			lang, best = model.Language(base), q
+		}
+	}
+	return lang
+}
@@ -0,0 +1,46 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		versions []int
+		err      error
+	}{
+		{header: ""},
+		{header: "*"},
+		{header: `"3"`, versions: []int{3}},
+		{header: `W/"3"`, err: model.ErrVersionMismatch},
+		{header: `"1", "2"`, versions: []int{1, 2}},
+		{header: `"1",W/"2" ,  "5"`, versions: []int{1, 5}},
+		{header: `3`, err: model.ErrInvalidHeader},
+		{header: `"0"`, err: model.ErrInvalidHeader},
+		{header: `"abc"`, err: model.ErrInvalidHeader},
+		{header: `"1", `, err: model.ErrInvalidHeader},
+		{header: `"1", *`, err: model.ErrInvalidHeader},
+	}
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			r := httptest.NewRequest("PATCH", "/", nil)
+			if tt.header != "" {
+				r.Header.Set("If-Match", tt.header)
+			}
+			versions, err := ParseIfMatch(r)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if !reflect.DeepEqual(versions, tt.versions) {
+				t.Errorf("versions = %v, want %v", versions, tt.versions)
+			}
+		})
+	}
+}
@@ -3,51 +3,103 @@ package helper
 import (
 	"encoding/json"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
 	"github.com/b0pof/avito-internship/internal/repository"
 	"github.com/b0pof/avito-internship/internal/usecase"
 )
 
-func ParseTenderFromBody(r *http.Request) (usecase.CreateTenderInput, error) {
-	var tender usecase.CreateTenderInput
-	if err := json.NewDecoder(r.Body).Decode(&tender); err != nil {
-		return usecase.CreateTenderInput{}, model.ErrInvalidBody
+// decodeBody decodes the JSON request body, rejecting unknown fields, and
+// checks it against the validate tags of T.
+func decodeBody[T any](r *http.Request) (T, error) {
+	var body T
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+	if err := d.Decode(&body); err != nil {
+		var zero T
+		if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+			return zero, validate.Field(model.ErrInvalidBody, strings.Trim(field, `"`), "unknown_field")
+		}
+		return zero, model.ErrInvalidBody
+	}
+	if err := validate.Struct(body); err != nil {
+		var zero T
+		return zero, err
 	}
-	return tender, nil
+	return body, nil
+}
+
+func ParseTenderFromBody(r *http.Request) (usecase.CreateTenderInput, error) {
+	return decodeBody[usecase.CreateTenderInput](r)
 }
 
 func ParseBidFromBody(r *http.Request) (repository.CreateBidInput, error) {
-	var bid repository.CreateBidInput
-	if err := json.NewDecoder(r.Body).Decode(&bid); err != nil {
-		return repository.CreateBidInput{}, model.ErrInvalidBody
-	}
-	return bid, nil
+	return decodeBody[repository.CreateBidInput](r)
 }
 
+// UpdateTenderInfo is a partial tender update: omitted fields are kept and
+// null clears a field.
 type UpdateTenderInfo struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	ServiceType string `json:"serviceType"`
+	Name               model.Optional[string]       `json:"name" validate:"max=100"`
+	Description        model.Optional[string]       `json:"description" validate:"max=500"`
+	ServiceType        model.Optional[string]       `json:"serviceType" validate:"oneof=Construction Delivery Manufacture"`
+	SubmissionDeadline model.Optional[time.Time]    `json:"submissionDeadline"`
+	Budget             model.Optional[model.Amount] `json:"budget"`
+	BudgetCurrency     model.Optional[string]       `json:"budgetCurrency"`
+	EnforceBudget      model.Optional[bool]         `json:"enforceBudget"`
 }
 
 func ParseUpdateTenderInfo(r *http.Request) (UpdateTenderInfo, error) {
-	var info UpdateTenderInfo
-	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
-		return UpdateTenderInfo{}, model.ErrInvalidBody
-	}
-	return info, nil
+	return decodeBody[UpdateTenderInfo](r)
 }
 
+// UpdateBidInfo is a partial bid update, like UpdateTenderInfo.
 type UpdateBidInfo struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        model.Optional[string]       `json:"name" validate:"max=100"`
+	Description model.Optional[string]       `json:"description" validate:"max=500"`
+	Price       model.Optional[model.Amount] `json:"price"`
+	Currency    model.Optional[string]       `json:"currency"`
 }
 
 func ParseUpdateBidInfo(r *http.Request) (UpdateBidInfo, error) {
-	var info UpdateBidInfo
-	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
-		return UpdateBidInfo{}, model.ErrInvalidBody
-	}
-	return info, nil
+	return decodeBody[UpdateBidInfo](r)
+}
+
+func ParseLoginFromBody(r *http.Request) (usecase.LoginInput, error) {
+	return decodeBody[usecase.LoginInput](r)
+}
+
+func ParseAPIKeyFromBody(r *http.Request) (usecase.CreateAPIKeyInput, error) {
+	return decodeBody[usecase.CreateAPIKeyInput](r)
+}
+
+func ParseCriteriaFromBody(r *http.Request) ([]model.Criterion, error) {
+	return decodeBody[[]model.Criterion](r)
+}
+
+func ParseScoresFromBody(r *http.Request) ([]model.BidScore, error) {
+	return decodeBody[[]model.BidScore](r)
+}
+
+func ParseLotFromBody(r *http.Request) (usecase.CreateLotInput, error) {
+	return decodeBody[usecase.CreateLotInput](r)
+}
+
+func ParseUpdateLotFromBody(r *http.Request) (usecase.UpdateLotInput, error) {
+	return decodeBody[usecase.UpdateLotInput](r)
+}
+
+func ParseQuestionFromBody(r *http.Request) (usecase.AskQuestionInput, error) {
+	return decodeBody[usecase.AskQuestionInput](r)
+}
+
+func ParseAnswerFromBody(r *http.Request) (usecase.AnswerQuestionInput, error) {
+	return decodeBody[usecase.AnswerQuestionInput](r)
+}
+
+func ParseInvitationFromBody(r *http.Request) (usecase.CreateInvitationInput, error) {
+	return decodeBody[usecase.CreateInvitationInput](r)
 }
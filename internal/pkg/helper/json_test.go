@@ -0,0 +1,15 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+func TestParseUpdateTenderInfoRejectsNUL(t *testing.T) {
+	r := httptest.NewRequest("PATCH", "/", strings.NewReader(`{"name": "null\u0000byte"}`))
+	_, err := ParseUpdateTenderInfo(r)
+	checkField(t, err, model.ErrInvalidBody, "name")
+}
@@ -0,0 +1,96 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/b0pof/avito-internship/internal/delivery/dto"
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+// ParsePagination reads limit and offset, or the cursor if the parameter is
+// present: an empty cursor requests the first page, the following ones are
+// requested by nextCursor of the previous page. total=true asks to count the
+// whole list.
+func ParsePagination(r *http.Request) (repository.Pagination, error) {
+	limit, offset, err := ParseLimitOffset(r)
+	if err != nil {
+		return repository.Pagination{}, err
+	}
+	p := repository.Pagination{
+		Limit:  limit,
+		Offset: offset,
+	}
+	query := r.URL.Query()
+	if value, ok := query["total"]; ok {
+		p.Total, err = strconv.ParseBool(value[0])
+		if err != nil {
+			return repository.Pagination{}, validate.Field(model.ErrInvalidQueryParam, "total", "boolean")
+		}
+	}
+
+	value, ok := query["cursor"]
+	if !ok {
+		return p, nil
+	}
+	if query.Has("offset") {
+		return repository.Pagination{}, validate.Field(model.ErrInvalidQueryParam, "offset", "with_cursor")
+	}
+	if limit == 0 {
+		return repository.Pagination{}, validate.Field(model.ErrInvalidQueryParam, "limit", "cursor_limit", 1, _maxLimit)
+	}
+	p.Keyset = true
+	if value[0] == "" {
+		return p, nil
+	}
+	cursor, ok := decodeCursor(value[0])
+	if !ok {
+		return repository.Pagination{}, validate.Field(model.ErrInvalidQueryParam, "cursor", "invalid_cursor")
+	}
+	p.Cursor = &cursor
+	return p, nil
+}
+
+// RespondPage responds with the page of a list. Pages requested by cursor are
+// wrapped with the cursor of the next page, offset pages are sent as is with
+// the requested total in X-Total-Count.
+func RespondPage[T any](w http.ResponseWriter, r *http.Request, page model.Page[T], p repository.Pagination) {
+	if !p.Keyset {
+		if page.Total != nil {
+			w.Header().Set("X-Total-Count", strconv.Itoa(*page.Total))
+		}
+		Respond(r.Context(), w, 200, page.Items)
+		return
+	}
+	resp := dto.PageResponse[T]{
+		Items: page.Items,
+		Total: page.Total,
+	}
+	if page.Next != nil {
+		next := encodeCursor(*page.Next)
+		resp.NextCursor = &next
+	}
+	Respond(r.Context(), w, 200, resp)
+}
+
+// Cursors are opaque to clients: base64url-encoded JSON of model.Cursor.
+func encodeCursor(c model.Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(value string) (model.Cursor, bool) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return model.Cursor{}, false
+	}
+	var c model.Cursor
+	if err = json.Unmarshal(data, &c); err != nil || !validate.UUID(c.ID) {
+		return model.Cursor{}, false
+	}
+	return c, true
+}
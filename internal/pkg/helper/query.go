@@ -2,16 +2,24 @@ package helper
 
 import (
 	"net/http"
+	"slices"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/gorilla/mux"
 
 	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/money"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
+	"github.com/b0pof/avito-internship/internal/repository"
 )
 
 const (
 	_defaultLimit  = 5
 	_defaultOffset = 0
+	_maxLimit      = 50
+	_maxFeedback   = 1000
 )
 
 func ParseLimitOffset(r *http.Request) (int, int, error) {
@@ -23,8 +31,8 @@ func ParseLimitOffset(r *http.Request) (int, int, error) {
 		limit = _defaultLimit
 	} else {
 		limit, err = strconv.Atoi(limitStr[0])
-		if err != nil {
-			return 0, 0, model.ErrInvalidQueryParam
+		if err != nil || limit < 0 || limit > _maxLimit {
+			return 0, 0, validate.Field(model.ErrInvalidQueryParam, "limit", "int_range", 0, _maxLimit)
 		}
 	}
 	offsetStr, ok := r.URL.Query()["offset"]
@@ -32,56 +40,189 @@ func ParseLimitOffset(r *http.Request) (int, int, error) {
 		offset = _defaultOffset
 	} else {
 		offset, err = strconv.Atoi(offsetStr[0])
-		if err != nil {
-			return 0, 0, model.ErrInvalidQueryParam
+		if err != nil || offset < 0 {
+			return 0, 0, validate.Field(model.ErrInvalidQueryParam, "offset", "non_negative")
 		}
 	}
 	return limit, offset, nil
 }
 
-func ParseServiseTypes(r *http.Request) []string {
+func ParseServiseTypes(r *http.Request) ([]string, error) {
 	types, _ := r.URL.Query()["service_type"]
-	return types
+	for _, t := range types {
+		if t != "Construction" && t != "Delivery" && t != "Manufacture" {
+			return nil, validate.Field(model.ErrInvalidQueryParam, "service_type", "one_of", "Construction, Delivery, Manufacture")
+		}
+	}
+	return types, nil
 }
 
-func ParseUsername(r *http.Request) string {
-	username, _ := r.URL.Query()["username"]
-	if len(username) == 0 {
-		return ""
+// parseIDVar returns the path parameter holding an ID, which must be a UUID.
+func parseIDVar(r *http.Request, name string) (string, error) {
+	id := mux.Vars(r)[name]
+	if !validate.UUID(id) {
+		return "", validate.Field(model.ErrInvalidPathParam, name, "uuid")
 	}
-	return username[0]
+	return id, nil
 }
 
-func ParseTenderID(r *http.Request) string {
-	tenderID, _ := mux.Vars(r)["tenderId"]
-	return tenderID
+func ParseTenderID(r *http.Request) (string, error) {
+	return parseIDVar(r, "tenderId")
 }
 
 func ParseVersion(r *http.Request) (int, error) {
 	version, err := strconv.Atoi(mux.Vars(r)["version"])
-	if err != nil {
-		return 0, model.ErrInvalidPathParam
+	if err != nil || version < 1 {
+		return 0, validate.Field(model.ErrInvalidPathParam, "version", "positive")
 	}
 	return version, nil
 }
 
-func ParseBidID(r *http.Request) string {
-	bidID, _ := mux.Vars(r)["bidId"]
-	return bidID
+func ParseBidID(r *http.Request) (string, error) {
+	return parseIDVar(r, "bidId")
+}
+
+// parseEnum returns the required query parameter, which must be one of the
+// allowed values.
+func parseEnum(r *http.Request, name string, allowed ...string) (string, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return "", validate.Field(model.ErrInvalidQueryParam, name, "required")
+	}
+	if !slices.Contains(allowed, value) {
+		return "", validate.Field(model.ErrInvalidQueryParam, name, "one_of", strings.Join(allowed, ", "))
+	}
+	return value, nil
+}
+
+func ParseTenderStatus(r *http.Request) (string, error) {
+	return parseEnum(r, "status", model.TenderStatusCreated, model.TenderStatusPublished, model.TenderStatusClosed)
+}
+
+func ParseBidStatus(r *http.Request) (string, error) {
+	return parseEnum(r, "status", model.BidStatusCreated, model.BidStatusPublished, model.BidStatusCanceled)
+}
+
+func ParseDecision(r *http.Request) (string, error) {
+	return parseEnum(r, "decision", "Approved", "Rejected")
 }
 
-func ParseStatus(r *http.Request) string {
-	status, _ := r.URL.Query()["status"]
-	if len(status) == 0 {
+// ParseFeedback returns the required "bidFeedback" query parameter.
+func ParseFeedback(r *http.Request) (string, error) {
+	feedback := r.URL.Query().Get("bidFeedback")
+	if feedback == "" {
+		return "", validate.Field(model.ErrInvalidQueryParam, "bidFeedback", "required")
+	}
+	if utf8.RuneCountInString(feedback) > _maxFeedback {
+		return "", validate.Field(model.ErrInvalidQueryParam, "bidFeedback", "max_length", _maxFeedback)
+	}
+	return feedback, nil
+}
+
+func ParseAuthorUsername(r *http.Request) string {
+	username, _ := r.URL.Query()["authorUsername"]
+	if len(username) == 0 {
 		return ""
 	}
-	return status[0]
+	return username[0]
+}
+
+// ParseDiffVersions returns values of "from" and "to" query parameters. Both are required.
+func ParseDiffVersions(r *http.Request) (int, int, error) {
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil || from < 1 {
+		return 0, 0, validate.Field(model.ErrInvalidQueryParam, "from", "positive")
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil || to < 1 {
+		return 0, 0, validate.Field(model.ErrInvalidQueryParam, "to", "positive")
+	}
+	return from, to, nil
 }
 
-func ParseDecision(r *http.Request) string {
-	dec, _ := r.URL.Query()["decision"]
-	if len(dec) == 0 {
+func ParseOrganizationID(r *http.Request) (string, error) {
+	return parseIDVar(r, "organizationId")
+}
+
+func ParseKeyID(r *http.Request) (string, error) {
+	return parseIDVar(r, "keyId")
+}
+
+func ParseRole(r *http.Request) string {
+	role, _ := r.URL.Query()["role"]
+	if len(role) == 0 {
 		return ""
 	}
-	return dec[0]
+	return role[0]
+}
+
+func ParseMemberUsername(r *http.Request) string {
+	username, _ := mux.Vars(r)["username"]
+	return username
+}
+
+func ParseAuditEntity(r *http.Request) (string, string, error) {
+	query := r.URL.Query()
+	entityID := query.Get("id")
+	if entityID != "" && !validate.UUID(entityID) {
+		return "", "", validate.Field(model.ErrInvalidQueryParam, "id", "uuid")
+	}
+	return query.Get("entity"), entityID, nil
+}
+
+func ParseBidSort(r *http.Request) (string, error) {
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		return repository.BidSortName, nil
+	}
+	if !repository.IsBidSort(sort) {
+		return "", validate.Field(model.ErrInvalidQueryParam, "sort", "one_of", strings.Join(repository.BidSorts(), ", "))
+	}
+	return sort, nil
+}
+
+// ParseBudgetRange parses optional budget_min and budget_max bounds.
+func ParseBudgetRange(r *http.Request) (*model.Amount, *model.Amount, error) {
+	var bounds [2]*model.Amount
+	for i, key := range []string{"budget_min", "budget_max"} {
+		value := r.URL.Query().Get(key)
+		if value == "" {
+			continue
+		}
+		amount := model.Amount(value)
+		if !money.IsAmount(amount) {
+			return nil, nil, validate.Field(model.ErrInvalidQueryParam, key, "invalid_amount")
+		}
+		bounds[i] = &amount
+	}
+	return bounds[0], bounds[1], nil
+}
+
+func ParseBudgetCurrency(r *http.Request) (string, error) {
+	currency := r.URL.Query().Get("budget_currency")
+	if currency != "" && !money.IsCurrency(currency) {
+		return "", validate.Field(model.ErrInvalidQueryParam, "budget_currency", "invalid_currency")
+	}
+	return currency, nil
+}
+
+func ParseLotID(r *http.Request) (string, error) {
+	return parseIDVar(r, "lotId")
+}
+
+// ParseLotFilter returns the optional lotId query parameter.
+func ParseLotFilter(r *http.Request) (string, error) {
+	lotID := r.URL.Query().Get("lotId")
+	if lotID != "" && !validate.UUID(lotID) {
+		return "", validate.Field(model.ErrInvalidQueryParam, "lotId", "uuid")
+	}
+	return lotID, nil
+}
+
+func ParseQuestionID(r *http.Request) (string, error) {
+	return parseIDVar(r, "questionId")
+}
+
+func ParseInvitationID(r *http.Request) (string, error) {
+	return parseIDVar(r, "invitationId")
 }
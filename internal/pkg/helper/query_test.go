@@ -0,0 +1,85 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+const _tenderID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+func TestParseTenderID(t *testing.T) {
+	for _, id := range []string{"", "42", "3fa85f64-5717-4562-b3fc", _tenderID + "'; DROP TABLE tender;--"} {
+		r := mux.SetURLVars(httptest.NewRequest("GET", "/", nil), map[string]string{"tenderId": id})
+		_, err := ParseTenderID(r)
+		checkField(t, err, model.ErrInvalidPathParam, "tenderId")
+	}
+	r := mux.SetURLVars(httptest.NewRequest("GET", "/", nil), map[string]string{"tenderId": _tenderID})
+	if id, err := ParseTenderID(r); err != nil || id != _tenderID {
+		t.Errorf("ParseTenderID = %q, %v", id, err)
+	}
+}
+
+func TestParseQueryFieldDetails(t *testing.T) {
+	tests := []struct {
+		query string
+		field string
+	}{
+		{query: "lotId=1", field: "lotId"},
+		{query: "from=0&to=2", field: "from"},
+		{query: "from=1&to=x", field: "to"},
+		{query: "sort=date", field: "sort"},
+		{query: "budget_min=-1", field: "budget_min"},
+		{query: "budget_max=1e3", field: "budget_max"},
+		{query: "budget_currency=rub", field: "budget_currency"},
+		{query: "entity=tender&id=1", field: "id"},
+		{query: "status=Draft", field: "status"},
+		{query: "decision=", field: "decision"},
+		{query: "decision=approved", field: "decision"},
+		{query: "bidFeedback=", field: "bidFeedback"},
+		{query: "bidFeedback=" + strings.Repeat("x", _maxFeedback+1), field: "bidFeedback"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			var err error
+			switch tt.field {
+			case "lotId":
+				_, err = ParseLotFilter(r)
+			case "from", "to":
+				_, _, err = ParseDiffVersions(r)
+			case "sort":
+				_, err = ParseBidSort(r)
+			case "budget_min", "budget_max":
+				_, _, err = ParseBudgetRange(r)
+			case "budget_currency":
+				_, err = ParseBudgetCurrency(r)
+			case "id":
+				_, _, err = ParseAuditEntity(r)
+			case "status":
+				_, err = ParseTenderStatus(r)
+			case "decision":
+				_, err = ParseDecision(r)
+			case "bidFeedback":
+				_, err = ParseFeedback(r)
+			}
+			checkField(t, err, model.ErrInvalidQueryParam, tt.field)
+		})
+	}
+}
+
+func checkField(t *testing.T, err error, cause *model.Error, field string) {
+	t.Helper()
+	var e *model.Error
+	if !errors.As(err, &e) || !e.Is(cause) {
+		t.Fatalf("error = %v, want %s", err, cause.Code)
+	}
+	if len(e.Details) != 1 || e.Details[0].Field != field {
+		t.Errorf("details = %+v, want field %s", e.Details, field)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"github.com/b0pof/avito-internship/internal/delivery/dto"
 	"github.com/b0pof/avito-internship/pkg/logger"
 )
 
@@ -14,3 +15,14 @@ func Respond(ctx context.Context, w http.ResponseWriter, status int, data interf
 		logger.Error(ctx, "marshall error: "+err.Error())
 	}
 }
+
+// RespondError reports the error as application/problem+json with the status
+// of its domain error, in the language the client accepts.
+func RespondError(w http.ResponseWriter, r *http.Request, err error) {
+	lang := ParseLanguage(r)
+	problem := dto.NewProblem(err, r.URL.Path, lang)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.Header().Set("Content-Language", string(lang))
+	w.Header().Add("Vary", "Accept-Language")
+	Respond(r.Context(), w, problem.Status, problem)
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+	"github.com/b0pof/avito-internship/internal/pkg/helper"
+)
+
+const (
+	_bearerPrefix = "Bearer "
+	_apiKeyHeader = "X-API-Key"
+)
+
+type TokenParser interface {
+	Parse(token string) (model.Employee, error)
+}
+
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (model.Principal, error)
+}
+
+// NewAuthMiddleware puts the caller authenticated by a bearer token or an
+// organization API key into request context. Requests without credentials
+// pass through anonymously, so that usecases decide whether identity is required.
+func NewAuthMiddleware(tokens TokenParser, keys APIKeyAuthenticator) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			if key := r.Header.Get(_apiKeyHeader); key != "" {
+				p, err := keys.AuthenticateAPIKey(ctx, key)
+				if err != nil {
+					helper.RespondError(w, r, err)
+					return
+				}
+				next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(ctx, p)))
+				return
+			}
+
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			token, ok := strings.CutPrefix(header, _bearerPrefix)
+			if !ok {
+				helper.RespondError(w, r, model.ErrInvalidToken)
+				return
+			}
+			user, err := tokens.Parse(token)
+			if err != nil {
+				helper.RespondError(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(auth.WithPrincipal(ctx, model.Principal{
+				UserID:   user.ID,
+				Username: user.Username,
+			})))
+		})
+	}
+}
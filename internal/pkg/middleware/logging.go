@@ -2,10 +2,11 @@ package middleware
 
 import (
 	"bufio"
+	"crypto/rand"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
-	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -16,8 +17,6 @@ import (
 
 var ErrHijackAssertion = errors.New("type assertion to http.Hijacker failed")
 
-var currRequestID uint64
-
 type responseWriterInterceptor struct {
 	w          http.ResponseWriter
 	statusCode int
@@ -54,17 +53,31 @@ func (wi *responseWriterInterceptor) GetStatusCode() int {
 	return wi.statusCode
 }
 
+// newRequestID returns a random UUID, so that request IDs recorded to logs and
+// audit stay unique across restarts and replicas. It is empty if no random
+// bytes could be read.
+func newRequestID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	b[6] = b[6]&0x0f | 0x40 // version 4
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
+
 func NewLoggingMiddleware(l *slog.Logger) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			atomic.AddUint64(&currRequestID, 1)
-			requestLogger := l.With(slog.Uint64("requestID", currRequestID))
+			requestID := newRequestID()
+			requestLogger := l.With(slog.String("requestID", requestID))
 			requestLogger.Info("new",
 				slog.String("method", r.Method),
 				slog.String("uri", r.RequestURI))
 
 			wi := newResponseWriterInterceptor(w)
 			ctx := logger.WithContext(r.Context(), requestLogger)
+			ctx = logger.WithRequestID(ctx, requestID)
 			start := time.Now()
 			next.ServeHTTP(wi, r.Clone(ctx))
 			dur := time.Since(start)
@@ -0,0 +1,21 @@
+package middleware
+
+import (
+	"testing"
+
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
+)
+
+func TestNewRequestID(t *testing.T) {
+	seen := make(map[string]struct{})
+	for i := 0; i < 100; i++ {
+		id := newRequestID()
+		if !validate.UUID(id) {
+			t.Fatalf("request ID %q is not a UUID", id)
+		}
+		if _, ok := seen[id]; ok {
+			t.Fatalf("request ID %q repeats", id)
+		}
+		seen[id] = struct{}{}
+	}
+}
@@ -0,0 +1,95 @@
+package money
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+// amounts are kept as decimal strings end to end, so that no precision is lost
+// to floating point; up to 15 integer and 2 fractional digits are accepted.
+var _amountRe = regexp.MustCompile(`^(0|[1-9][0-9]{0,14})(\.[0-9]{1,2})?$`)
+
+// _currencies holds active ISO 4217 currency codes.
+var _currencies = func() map[string]struct{} {
+	codes := strings.Fields(`
+		AED AFN ALL AMD ANG AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BRL
+		BSD BTN BWP BYN BZD CAD CDF CHF CLP CNY COP CRC CUP CVE CZK DJF DKK DOP DZD EGP
+		ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR
+		IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP LKR LRD LSL
+		LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MYR MZN NAD NGN NIO NOK NPR
+		NZD OMR PAB PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD
+		SHP SLE SOS SRD SSP STN SVC SYP SZL THB TJS TMT TND TOP TRY TTD TWD TZS UAH UGX
+		USD UYU UZS VES VND VUV WST XAF XCD XOF XPF YER ZAR ZMW ZWL`)
+	set := make(map[string]struct{}, len(codes))
+	for _, c := range codes {
+		set[c] = struct{}{}
+	}
+	return set
+}()
+
+// IsAmount reports whether the amount is a positive decimal.
+func IsAmount(amount model.Amount) bool {
+	if !_amountRe.MatchString(string(amount)) {
+		return false
+	}
+	r, _ := new(big.Rat).SetString(string(amount))
+	return r.Sign() > 0
+}
+
+func IsCurrency(code string) bool {
+	_, ok := _currencies[code]
+	return ok
+}
+
+// ValidatePrice checks that amount and currency are either both set and
+// valid or both absent.
+func ValidatePrice(amount *model.Amount, currency *string) error {
+	if amount == nil && currency == nil {
+		return nil
+	}
+	if amount == nil || currency == nil {
+		return model.ErrInvalidAttributeValue.Wrap("price_pair")
+	}
+	if !IsAmount(*amount) {
+		return model.ErrInvalidAttributeValue.Wrap("invalid_amount")
+	}
+	if !IsCurrency(*currency) {
+		return model.ErrInvalidAttributeValue.Wrap("invalid_currency")
+	}
+	return nil
+}
+
+// Compare compares two valid amounts exactly and returns -1, 0 or +1.
+func Compare(a, b model.Amount) int {
+	x, _ := new(big.Rat).SetString(string(a))
+	y, _ := new(big.Rat).SetString(string(b))
+	return x.Cmp(y)
+}
+
+// Undercuts reports whether the valid price is lower than best at least by step.
+func Undercuts(price, best, step model.Amount) bool {
+	x, _ := new(big.Rat).SetString(string(price))
+	y, _ := new(big.Rat).SetString(string(best))
+	z, _ := new(big.Rat).SetString(string(step))
+	return x.Add(x, z).Cmp(y) <= 0
+}
+
+// CheckBudget checks the bid price against the tender budget when the tender enforces it.
+func CheckBudget(tender model.Tender, price *model.Amount, currency *string) error {
+	if !tender.EnforceBudget || tender.Budget == nil || tender.BudgetCurrency == nil {
+		return nil
+	}
+	if price == nil || currency == nil {
+		return model.ErrInvalidAttributeValue.Wrap("price_required")
+	}
+	if *currency != *tender.BudgetCurrency {
+		return model.ErrInvalidAttributeValue.Wrap("budget_currency_mismatch")
+	}
+	if Compare(*price, *tender.Budget) > 0 {
+		return model.ErrInvalidAttributeValue.Wrap("price_over_budget")
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package policy
+
+// Action is an operation guarded by organization roles.
+type Action string
+
+const (
+	ActionCreateTender  Action = "tender:create"
+	ActionPublishTender Action = "tender:publish"
+	ActionEditTender    Action = "tender:edit"
+	ActionViewTender    Action = "tender:view"
+	ActionAnswerTender  Action = "tender:answer"
+	ActionViewBids      Action = "bid:view"
+	ActionDecideBid     Action = "bid:decide"
+	ActionReviewBid     Action = "bid:review"
+	ActionScoreBid      Action = "bid:score"
+	ActionManageMembers Action = "organization:members"
+	ActionManageAPIKeys Action = "organization:api_keys"
+	ActionViewAudit     Action = "organization:audit"
+)
+
+const (
+	RoleAdmin         = "Admin"
+	RoleTenderManager = "TenderManager"
+	RoleReviewer      = "Reviewer"
+	RoleViewer        = "Viewer"
+)
+
+// APIKeyRole is the role organization API keys act with.
+const APIKeyRole = RoleTenderManager
+
+var _permissions = map[string][]Action{
+	RoleAdmin: {
+		ActionCreateTender, ActionPublishTender, ActionEditTender, ActionViewTender, ActionAnswerTender,
+		ActionViewBids, ActionDecideBid, ActionReviewBid, ActionScoreBid,
+		ActionManageMembers, ActionManageAPIKeys, ActionViewAudit,
+	},
+	RoleTenderManager: {
+		ActionCreateTender, ActionPublishTender, ActionEditTender, ActionViewTender, ActionAnswerTender,
+		ActionViewBids, ActionViewAudit,
+	},
+	RoleReviewer: {
+		ActionViewTender, ActionViewBids, ActionDecideBid, ActionReviewBid, ActionScoreBid,
+	},
+	RoleViewer: {
+		ActionViewTender, ActionViewBids,
+	},
+}
+
+// Allows reports whether the role permits the action.
+func Allows(role string, action Action) bool {
+	for _, a := range _permissions[role] {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// RolesAllowing returns every role that permits the action.
+func RolesAllowing(action Action) []string {
+	roles := make([]string, 0, len(_permissions))
+	for _, role := range []string{RoleAdmin, RoleTenderManager, RoleReviewer, RoleViewer} {
+		if Allows(role, action) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+func IsRole(role string) bool {
+	_, ok := _permissions[role]
+	return ok
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/config"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// Local keeps blobs as files in a single directory, named by their keys.
+type Local struct {
+	dir string
+}
+
+func NewLocal(cfg config.Storage) (*Local, error) {
+	if err := os.MkdirAll(cfg.Dir, 0o750); err != nil {
+		return nil, errors.Wrap(err, "create storage directory")
+	}
+	return &Local{
+		dir: cfg.Dir,
+	}, nil
+}
+
+// Put writes the blob to a temporary file first, so that a failed upload
+// never leaves a partial blob under the key.
+func (s *Local) Put(_ context.Context, key string, r io.Reader) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return errors.Wrap(err, "create temporary file")
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err = io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		return errors.Wrap(err, "write blob")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "close blob")
+	}
+	return errors.Wrap(os.Rename(tmp.Name(), path), "store blob")
+}
+
+func (s *Local) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "open blob")
+	}
+	return f, nil
+}
+
+func (s *Local) Delete(_ context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrap(err, "delete blob")
+	}
+	return nil
+}
+
+// path rejects keys that would escape the storage directory.
+func (s *Local) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || key[0] == '.' {
+		return "", errors.Errorf("invalid blob key %q", key)
+	}
+	return filepath.Join(s.dir, key), nil
+}
@@ -0,0 +1,163 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+var _uuidRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// Field returns the error cause with the reason a single field is rejected,
+// given by its catalog text key.
+func Field(cause *model.Error, field, key string, params ...any) error {
+	return cause.WithDetails(model.NewFieldError(field, key, params...))
+}
+
+// UUID reports whether the value is a UUID.
+func UUID(value string) bool {
+	return _uuidRe.MatchString(value)
+}
+
+// optional is implemented by model.Optional: only present values are checked.
+type optional interface {
+	Present() (any, bool)
+}
+
+// Struct checks a request body, a struct or a slice of structs, against the
+// `validate` tags of its fields and reports all violations at once.
+// Rules are comma-separated:
+//
+//	required  the field is present and not empty
+//	max=N     at most N characters
+//	min=N     at least N characters
+//	oneof=A B one of the listed values
+//	uuid      a UUID
+//
+// Absent and empty fields are only checked by required. Values with the NUL
+// character, which Postgres text cannot hold, break any rule.
+func Struct(v any) error {
+	var fields []model.FieldError
+	check(reflect.ValueOf(v), "", &fields)
+	if len(fields) == 0 {
+		return nil
+	}
+	return model.ErrInvalidBody.WithDetails(fields...)
+}
+
+func check(v reflect.Value, path string, fields *[]model.FieldError) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			check(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if !f.IsExported() || name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			if path != "" {
+				name = path + "." + name
+			}
+			rules := f.Tag.Get("validate")
+			if rules == "" {
+				continue
+			}
+			if reason := checkRules(v.Field(i), rules); reason.Key != "" {
+				*fields = append(*fields, model.NewFieldError(name, reason.Key, reason.Params...))
+			}
+		}
+	}
+}
+
+// checkRules returns the reason the value breaks the rules, or an empty text
+// if it does not.
+func checkRules(v reflect.Value, rules string) model.Text {
+	value, present := fieldValue(v)
+	if strings.ContainsRune(value, 0) {
+		return model.NewText("nul_character")
+	}
+	for _, rule := range strings.Split(rules, ",") {
+		name, arg, _ := strings.Cut(rule, "=")
+		if name == "required" {
+			if !present || value == "" {
+				return model.NewText("required")
+			}
+			continue
+		}
+		if !present || value == "" {
+			return model.Text{}
+		}
+		switch name {
+		case "max":
+			n, _ := strconv.Atoi(arg)
+			if utf8.RuneCountInString(value) > n {
+				return model.NewText("max_length", n)
+			}
+		case "min":
+			n, _ := strconv.Atoi(arg)
+			if utf8.RuneCountInString(value) < n {
+				return model.NewText("min_length", n)
+			}
+		case "oneof":
+			allowed := strings.Fields(arg)
+			if !contains(allowed, value) {
+				return model.NewText("one_of", strings.Join(allowed, ", "))
+			}
+		case "uuid":
+			if !UUID(value) {
+				return model.NewText("uuid")
+			}
+		default:
+			panic("unknown validation rule " + name)
+		}
+	}
+	return model.Text{}
+}
+
+// fieldValue returns a string field value and whether it is present.
+func fieldValue(v reflect.Value) (string, bool) {
+	if o, ok := v.Interface().(optional); ok {
+		value, present := o.Present()
+		if !present {
+			return "", false
+		}
+		v = reflect.ValueOf(value)
+	}
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.String {
+		return "", true
+	}
+	return v.String(), true
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
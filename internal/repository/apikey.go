@@ -0,0 +1,145 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+type CreateAPIKeyInput struct {
+	OrganizationID string
+	Name           string
+	Prefix         string
+	KeyHash        string
+	CreatedBy      string
+}
+
+func (r *Repository) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (model.APIKey, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.APIKey{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `INSERT INTO organization_api_key (organization_id, name, key_prefix, key_hash, created_by)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id, name, key_prefix, created_at, last_used_at, revoked_at;`
+
+	var key model.APIKey
+	err = tx.GetContext(ctx, &key, q, input.OrganizationID, input.Name, input.Prefix, input.KeyHash, input.CreatedBy)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return model.APIKey{}, model.ErrInternal
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityOrganization,
+		EntityID:   input.OrganizationID,
+		Action:     _auditActionCreateAPIKey,
+		After:      key,
+	}); err != nil {
+		return model.APIKey{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.APIKey{}, model.ErrInternal
+	}
+	return key, nil
+}
+
+func (r *Repository) GetOrganizationAPIKeys(ctx context.Context, orgID string) ([]model.APIKey, error) {
+	q := `SELECT id, name, key_prefix, created_at, last_used_at, revoked_at
+			FROM organization_api_key
+			WHERE organization_id = $1
+			ORDER BY created_at;`
+
+	keys := make([]model.APIKey, 0)
+	if err := r.db.SelectContext(ctx, &keys, q, orgID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return keys, nil
+}
+
+type RevokeAPIKeyInput struct {
+	OrganizationID string
+	KeyID          string
+}
+
+func (r *Repository) RevokeAPIKey(ctx context.Context, input RevokeAPIKeyInput) (model.APIKey, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.APIKey{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `SELECT id, name, key_prefix, created_at, last_used_at, revoked_at
+			FROM organization_api_key
+			WHERE id = $1 AND organization_id = $2
+			FOR UPDATE;`
+
+	var before model.APIKey
+	if err = tx.GetContext(ctx, &before, q, input.KeyID, input.OrganizationID); err != nil {
+		return model.APIKey{}, model.ErrAPIKeyNotFound
+	}
+
+	q = `UPDATE organization_api_key
+			SET revoked_at = COALESCE(revoked_at, CURRENT_TIMESTAMP)
+			WHERE id = $1
+			RETURNING id, name, key_prefix, created_at, last_used_at, revoked_at;`
+
+	var key model.APIKey
+	if err = tx.GetContext(ctx, &key, q, input.KeyID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.APIKey{}, model.ErrInternal
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityOrganization,
+		EntityID:   input.OrganizationID,
+		Action:     _auditActionRevokeAPIKey,
+		Before:     before,
+		After:      key,
+	}); err != nil {
+		return model.APIKey{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.APIKey{}, model.ErrInternal
+	}
+	return key, nil
+}
+
+type APIKeyOwner struct {
+	ID             string `db:"id"`
+	OrganizationID string `db:"organization_id"`
+	CreatedBy      string `db:"created_by"`
+}
+
+// UseAPIKey finds an active key by its hash and records the time it was used.
+// A key stops working once its creator leaves the organization.
+func (r *Repository) UseAPIKey(ctx context.Context, keyHash string) (APIKeyOwner, error) {
+	q := `UPDATE organization_api_key k
+			SET last_used_at = CURRENT_TIMESTAMP
+			WHERE k.key_hash = $1
+				AND k.revoked_at IS NULL
+				AND EXISTS (
+					SELECT 1
+					FROM organization_responsible r
+					WHERE r.organization_id = k.organization_id AND r.user_id = k.created_by
+				)
+			RETURNING k.id, k.organization_id, k.created_by;`
+
+	var owner APIKeyOwner
+	if err := r.db.GetContext(ctx, &owner, q, keyHash); err != nil {
+		return APIKeyOwner{}, model.ErrInvalidAPIKey
+	}
+	return owner, nil
+}
@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+// attachmentOwner describes where attachment sets of a versioned entity are kept.
+type attachmentOwner struct {
+	links        string
+	ownerColumn  string
+	versionTable string
+}
+
+var (
+	_tenderAttachments = attachmentOwner{
+		links:        "tender_attachment",
+		ownerColumn:  "tender_id",
+		versionTable: "tender_version",
+	}
+	_bidAttachments = attachmentOwner{
+		links:        "bid_attachment",
+		ownerColumn:  "bid_id",
+		versionTable: "bid_version",
+	}
+)
+
+// carryAttachments gives the latest version the attachment set of the version before it.
+func carryAttachments(ctx context.Context, tx *sqlx.Tx, o attachmentOwner, ownerID string) error {
+	q := fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, version, attachment_id)
+			SELECT %[2]s, version + 1, attachment_id
+			FROM %[1]s
+			WHERE %[2]s = $1
+				AND version = (SELECT MAX(version) - 1 FROM %[3]s WHERE %[2]s = $1);`,
+		o.links, o.ownerColumn, o.versionTable)
+
+	if _, err := tx.ExecContext(ctx, q, ownerID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
+
+// restoreAttachments gives the latest version the attachment set of the given one.
+func restoreAttachments(ctx context.Context, tx *sqlx.Tx, o attachmentOwner, ownerID string, version int) error {
+	q := fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, version, attachment_id)
+			SELECT %[2]s, (SELECT MAX(version) FROM %[3]s WHERE %[2]s = $1), attachment_id
+			FROM %[1]s
+			WHERE %[2]s = $1 AND version = $2;`,
+		o.links, o.ownerColumn, o.versionTable)
+
+	if _, err := tx.ExecContext(ctx, q, ownerID, version); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
+
+// attach stores the attachment and links it to the latest version of the owner.
+func attach(ctx context.Context, tx *sqlx.Tx, o attachmentOwner, ownerID string, a NewAttachment) error {
+	q := `INSERT INTO attachment (storage_key, filename, content_type, size, checksum, author_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id;`
+
+	var attachmentID string
+	err := tx.GetContext(ctx, &attachmentID, q, a.StorageKey, a.Filename, a.ContentType, a.Size, a.Checksum, a.AuthorID)
+	if err != nil {
+		if isInvalidInput(err) {
+			return model.ErrInvalidAttributeValue
+		}
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+
+	q = fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, version, attachment_id)
+			SELECT $1, MAX(version), $2
+			FROM %[3]s
+			WHERE %[2]s = $1;`,
+		o.links, o.ownerColumn, o.versionTable)
+
+	if _, err = tx.ExecContext(ctx, q, ownerID, attachmentID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
+
+// detach unlinks the attachment from the latest version of the owner. The blob
+// is kept, since earlier versions still refer to it.
+func detach(ctx context.Context, tx *sqlx.Tx, o attachmentOwner, ownerID, attachmentID string) error {
+	q := fmt.Sprintf(`DELETE FROM %[1]s
+			WHERE %[2]s = $1
+				AND attachment_id = $2
+				AND version = (SELECT MAX(version) FROM %[3]s WHERE %[2]s = $1);`,
+		o.links, o.ownerColumn, o.versionTable)
+
+	res, err := tx.ExecContext(ctx, q, ownerID, attachmentID)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return model.ErrAttachmentNotFound
+	}
+	return nil
+}
+
+func getAttachments(ctx context.Context, db sqlx.QueryerContext, o attachmentOwner, ownerID string, version int) ([]model.Attachment, error) {
+	q := fmt.Sprintf(`SELECT a.id, a.filename, a.content_type, a.size, a.checksum, a.storage_key, a.created_at
+			FROM attachment a
+				INNER JOIN %[1]s l ON a.id = l.attachment_id
+			WHERE l.%[2]s = $1
+				AND l.version = CASE
+					WHEN $2::int = 0 THEN (SELECT MAX(version) FROM %[3]s WHERE %[2]s = $1)
+					ELSE $2::int
+				END
+			ORDER BY a.created_at, a.id;`,
+		o.links, o.ownerColumn, o.versionTable)
+
+	attachments := make([]model.Attachment, 0)
+	if err := sqlx.SelectContext(ctx, db, &attachments, q, ownerID, version); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return attachments, nil
+}
+
+// getAttachment returns the attachment if any version of the owner has it.
+func getAttachment(ctx context.Context, db sqlx.QueryerContext, o attachmentOwner, ownerID, attachmentID string) (model.Attachment, error) {
+	q := fmt.Sprintf(`SELECT a.id, a.filename, a.content_type, a.size, a.checksum, a.storage_key, a.created_at
+			FROM attachment a
+			WHERE a.id = $2
+				AND EXISTS (SELECT 1 FROM %[1]s WHERE %[2]s = $1 AND attachment_id = a.id);`,
+		o.links, o.ownerColumn)
+
+	var attachment model.Attachment
+	if err := sqlx.GetContext(ctx, db, &attachment, q, ownerID, attachmentID); err != nil {
+		return model.Attachment{}, model.ErrAttachmentNotFound
+	}
+	return attachment, nil
+}
+
+// NewAttachment is an uploaded blob to be recorded.
+type NewAttachment struct {
+	StorageKey  string
+	Filename    string
+	ContentType string
+	Size        int64
+	Checksum    string
+	AuthorID    string
+}
+
+type AddAttachmentInput struct {
+	OwnerID          string
+	Attachment       NewAttachment
+	ExpectedVersions []int
+}
+
+type RemoveAttachmentInput struct {
+	OwnerID          string
+	AttachmentID     string
+	ExpectedVersions []int
+}
+
+type GetAttachmentsInput struct {
+	OwnerID string
+	// Version selects the attachment set of a version, zero means the latest one.
+	Version int
+}
+
+// AddTenderAttachment adds a tender version with the attachment added to its set.
+func (r *Repository) AddTenderAttachment(ctx context.Context, input AddAttachmentInput) (model.Tender, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Tender{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockTender(ctx, tx, input.OwnerID, input.ExpectedVersions)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	if err = copyTenderVersion(ctx, tx, input.OwnerID); err != nil {
+		return model.Tender{}, err
+	}
+	if err = carryAttachments(ctx, tx, _tenderAttachments, input.OwnerID); err != nil {
+		return model.Tender{}, err
+	}
+	if err = attach(ctx, tx, _tenderAttachments, input.OwnerID, input.Attachment); err != nil {
+		return model.Tender{}, err
+	}
+	tender, err := finishTender(ctx, tx, _auditActionAttach, &before, input.OwnerID)
+	return tender, err
+}
+
+// RemoveTenderAttachment adds a tender version without the attachment.
+func (r *Repository) RemoveTenderAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Tender, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Tender{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockTender(ctx, tx, input.OwnerID, input.ExpectedVersions)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	if err = copyTenderVersion(ctx, tx, input.OwnerID); err != nil {
+		return model.Tender{}, err
+	}
+	if err = carryAttachments(ctx, tx, _tenderAttachments, input.OwnerID); err != nil {
+		return model.Tender{}, err
+	}
+	if err = detach(ctx, tx, _tenderAttachments, input.OwnerID, input.AttachmentID); err != nil {
+		return model.Tender{}, err
+	}
+	tender, err := finishTender(ctx, tx, _auditActionDetach, &before, input.OwnerID)
+	return tender, err
+}
+
+func (r *Repository) GetTenderAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error) {
+	return getAttachments(ctx, r.db, _tenderAttachments, input.OwnerID, input.Version)
+}
+
+func (r *Repository) GetTenderAttachment(ctx context.Context, tenderID, attachmentID string) (model.Attachment, error) {
+	return getAttachment(ctx, r.db, _tenderAttachments, tenderID, attachmentID)
+}
+
+// AddBidAttachment adds a bid version with the attachment added to its set.
+func (r *Repository) AddBidAttachment(ctx context.Context, input AddAttachmentInput) (model.Bid, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Bid{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockBid(ctx, tx, input.OwnerID, input.ExpectedVersions)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if err = copyBidVersion(ctx, tx, input.OwnerID); err != nil {
+		return model.Bid{}, err
+	}
+	if err = carryAttachments(ctx, tx, _bidAttachments, input.OwnerID); err != nil {
+		return model.Bid{}, err
+	}
+	if err = attach(ctx, tx, _bidAttachments, input.OwnerID, input.Attachment); err != nil {
+		return model.Bid{}, err
+	}
+	bid, err := finishBid(ctx, tx, _auditActionAttach, &before, input.OwnerID)
+	return bid, err
+}
+
+// RemoveBidAttachment adds a bid version without the attachment.
+func (r *Repository) RemoveBidAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Bid, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Bid{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockBid(ctx, tx, input.OwnerID, input.ExpectedVersions)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if err = copyBidVersion(ctx, tx, input.OwnerID); err != nil {
+		return model.Bid{}, err
+	}
+	if err = carryAttachments(ctx, tx, _bidAttachments, input.OwnerID); err != nil {
+		return model.Bid{}, err
+	}
+	if err = detach(ctx, tx, _bidAttachments, input.OwnerID, input.AttachmentID); err != nil {
+		return model.Bid{}, err
+	}
+	bid, err := finishBid(ctx, tx, _auditActionDetach, &before, input.OwnerID)
+	return bid, err
+}
+
+func (r *Repository) GetBidAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error) {
+	return getAttachments(ctx, r.db, _bidAttachments, input.OwnerID, input.Version)
+}
+
+func (r *Repository) GetBidAttachment(ctx context.Context, bidID, attachmentID string) (model.Attachment, error) {
+	return getAttachment(ctx, r.db, _bidAttachments, bidID, attachmentID)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/money"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+const _auctionBidsQuery = `SELECT MIN(bv.price) AS best_price, COUNT(*) AS bids
+			FROM bid b
+				INNER JOIN bid_version bv
+					ON b.id = bv.bid_id
+					AND bv.version = (
+						SELECT MAX(version)
+						FROM bid_version
+						WHERE bid_id = b.id
+					)
+			WHERE b.tender_id = $1
+				AND ($2::uuid IS NULL AND b.lot_id IS NULL OR b.lot_id = $2::uuid)
+				AND b.status = 'Published';`
+
+// GetAuction returns the best price among published bids of the auction
+// tender, or of its lot when lotID is set. Drafts do not move the auction.
+func (r *Repository) GetAuction(ctx context.Context, tender model.Tender, lotID *string) (model.Auction, error) {
+	return getAuction(ctx, r.db, tender, lotID)
+}
+
+func getAuction(ctx context.Context, db sqlx.QueryerContext, tender model.Tender, lotID *string) (model.Auction, error) {
+	auction := model.Auction{
+		StartsAt: tender.AuctionStart,
+	}
+	if tender.BudgetCurrency != nil {
+		auction.Currency = *tender.BudgetCurrency
+	}
+	if tender.AuctionStep != nil {
+		auction.Step = *tender.AuctionStep
+	}
+	if tender.SubmissionDeadline != nil {
+		auction.EndsAt = *tender.SubmissionDeadline
+	}
+	if err := sqlx.GetContext(ctx, db, &auction, _auctionBidsQuery, tender.ID, lotID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Auction{}, model.ErrInternal
+	}
+	return auction, nil
+}
+
+// offerAuctionPrice checks a price offered on the tender while its auction runs:
+// the price has to undercut the best one, or the starting budget if there are
+// no bids yet, by the auction step. A price offered in the last minutes of the
+// auction extends it, in a new tender version, to the extension from now.
+// The tender is locked, so that concurrent offers are compared one by one.
+func offerAuctionPrice(ctx context.Context, tx *sqlx.Tx, tenderID string, lotID *string, price model.Amount) error {
+	// the auction flag and start are fixed when the tender is created, so an
+	// unlocked read tells whether the auction runs; only then is the tender
+	// locked, and offers on other tenders do not wait for the lock
+	tender, err := getTender(ctx, tx, tenderID)
+	if err != nil || !tender.AuctionStarted(time.Now()) {
+		return err
+	}
+	if tender, err = lockTender(ctx, tx, tenderID, nil); err != nil {
+		return err
+	}
+	now := time.Now()
+	if tender.Status != model.TenderStatusPublished || tender.SubmissionClosed(now) {
+		return model.ErrSubmissionClosed
+	}
+
+	auction, err := getAuction(ctx, tx, tender, lotID)
+	if err != nil {
+		return err
+	}
+	best := auction.BestPrice
+	if best == nil {
+		best = tender.Budget
+	}
+	if best != nil && !money.Undercuts(price, *best, auction.Step) {
+		return model.ErrAuctionStep
+	}
+
+	extension := time.Duration(tender.AuctionExtension) * time.Minute
+	if extension == 0 || tender.SubmissionDeadline.Sub(now) >= extension {
+		return nil
+	}
+	err = newVersionCopy(_tenderVersions).set("submission_deadline", now.Add(extension)).exec(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+	if err = carryAttachments(ctx, tx, _tenderAttachments, tenderID); err != nil {
+		return err
+	}
+	after, err := getTender(ctx, tx, tenderID)
+	if err != nil {
+		return err
+	}
+	return writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   tenderID,
+		Action:     _auditActionExtend,
+		Before:     tender,
+		After:      after,
+	})
+}
+
+// offerBidPrice is offerAuctionPrice for a new price of an existing bid.
+func offerBidPrice(ctx context.Context, tx *sqlx.Tx, bidID string, price model.Amount) error {
+	q := `SELECT tender_id, lot_id FROM bid WHERE id = $1;`
+
+	var bid struct {
+		TenderID string  `db:"tender_id"`
+		LotID    *string `db:"lot_id"`
+	}
+	if err := tx.GetContext(ctx, &bid, q, bidID); err != nil {
+		return model.ErrNoBidFound
+	}
+	return offerAuctionPrice(ctx, tx, bid.TenderID, bid.LotID, price)
+}
+
+// offerDraftPrice is offerAuctionPrice for the price of a draft being
+// published, which drafts do not move and bids published since were not
+// compared with. It returns the offered price, nil if the draft has none.
+func offerDraftPrice(ctx context.Context, tx *sqlx.Tx, bidID string) (*model.Amount, error) {
+	q := `SELECT b.tender_id, b.lot_id, bv.price
+			FROM bid b
+				INNER JOIN bid_version bv ON b.id = bv.bid_id
+			WHERE b.id = $1
+			ORDER BY bv.version DESC
+			LIMIT 1;`
+
+	var bid struct {
+		TenderID string        `db:"tender_id"`
+		LotID    *string       `db:"lot_id"`
+		Price    *model.Amount `db:"price"`
+	}
+	if err := tx.GetContext(ctx, &bid, q, bidID); err != nil {
+		return nil, model.ErrNoBidFound
+	}
+	if bid.Price == nil {
+		return nil, nil
+	}
+	return bid.Price, offerAuctionPrice(ctx, tx, bid.TenderID, bid.LotID, *bid.Price)
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+const (
+	AuditEntityTender       = "tender"
+	AuditEntityBid          = "bid"
+	AuditEntityOrganization = "organization"
+)
+
+const (
+	_auditActionCreate       = "create"
+	_auditActionEdit         = "edit"
+	_auditActionRollback     = "rollback"
+	_auditActionStatus       = "status"
+	_auditActionAward        = "award"
+	_auditActionDeadline     = "deadline"
+	_auditActionDecision     = "decision"
+	_auditActionFeedback     = "feedback"
+	_auditActionCriteria     = "criteria"
+	_auditActionScore        = "score"
+	_auditActionCreateLot    = "lot_create"
+	_auditActionEditLot      = "lot_edit"
+	_auditActionRollbackLot  = "lot_rollback"
+	_auditActionAwardLot     = "lot_award"
+	_auditActionAttach       = "attachment_add"
+	_auditActionDetach       = "attachment_remove"
+	_auditActionAsk          = "question_ask"
+	_auditActionAnswer       = "question_answer"
+	_auditActionInvite       = "invitation_add"
+	_auditActionUninvite     = "invitation_remove"
+	_auditActionExtend       = "auction_extend"
+	_auditActionCreateAPIKey = "api_key_create"
+	_auditActionRevokeAPIKey = "api_key_revoke"
+	_auditActionSetMember    = "member_set"
+	_auditActionRemoveMember = "member_remove"
+)
+
+type auditEvent struct {
+	EntityType string
+	EntityID   string
+	Action     string
+	Before     any
+	After      any
+}
+
+// writeAudit appends the event in the transaction of the change it describes.
+// Actor and request ID are taken from ctx; both are empty for system changes.
+func writeAudit(ctx context.Context, tx *sqlx.Tx, e auditEvent) error {
+	before, err := auditValue(e.Before)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	after, err := auditValue(e.After)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+
+	var actorID, apiKeyID, requestID *string
+	if p, ok := auth.PrincipalFromContext(ctx); ok {
+		actorID = &p.UserID
+		if p.IsAPIKey() {
+			// changes made with a key are attributed to the employee who issued it
+			actorID, apiKeyID = &p.KeyCreatorID, &p.APIKeyID
+		}
+	}
+	if id := logger.RequestID(ctx); id != "" {
+		requestID = &id
+	}
+
+	q := `INSERT INTO audit_event (entity_type, entity_id, action, actor_id, api_key_id, before, after, request_id)
+			VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7::jsonb, $8);`
+
+	if _, err = tx.ExecContext(ctx, q, e.EntityType, e.EntityID, e.Action, actorID, apiKeyID, before, after, requestID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
+
+func auditValue(v any) (*string, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
+}
+
+type GetAuditEventsInput struct {
+	EntityType string
+	EntityID   string
+	Limit      int
+	Offset     int
+}
+
+type auditEventRow struct {
+	ID         string    `db:"id"`
+	EntityType string    `db:"entity_type"`
+	EntityID   string    `db:"entity_id"`
+	Action     string    `db:"action"`
+	ActorID    *string   `db:"actor_id"`
+	APIKeyID   *string   `db:"api_key_id"`
+	Before     []byte    `db:"before"`
+	After      []byte    `db:"after"`
+	RequestID  *string   `db:"request_id"`
+	CreatedAt  time.Time `db:"created_at"`
+}
+
+func (r *Repository) GetAuditEvents(ctx context.Context, input GetAuditEventsInput) ([]model.AuditEvent, error) {
+	q := `SELECT id, entity_type, entity_id, action, actor_id, api_key_id,
+				before::text AS before, after::text AS after, request_id, created_at
+			FROM audit_event
+			WHERE entity_type = $1 AND entity_id = $2
+			ORDER BY created_at, id
+			LIMIT $3
+			OFFSET $4;`
+
+	rows := make([]auditEventRow, 0)
+	if err := r.db.SelectContext(ctx, &rows, q, input.EntityType, input.EntityID, input.Limit, input.Offset); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+
+	events := make([]model.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		events = append(events, model.AuditEvent{
+			ID:         row.ID,
+			EntityType: row.EntityType,
+			EntityID:   row.EntityID,
+			Action:     row.Action,
+			ActorID:    row.ActorID,
+			APIKeyID:   row.APIKeyID,
+			Before:     row.Before,
+			After:      row.After,
+			RequestID:  row.RequestID,
+			CreatedAt:  row.CreatedAt,
+		})
+	}
+	return events, nil
+}
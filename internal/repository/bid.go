@@ -2,37 +2,72 @@ package repository
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"slices"
+
+	"github.com/jmoiron/sqlx"
 
 	"github.com/b0pof/avito-internship/internal/model"
 	"github.com/b0pof/avito-internship/pkg/logger"
 )
 
 func (r *Repository) GetBidByID(ctx context.Context, bidID string) (model.Bid, error) {
-	q := `SELECT b.id, bv.name, b.status, b.author_type, b.author_id, bv.version, b.created_at
+	return getBid(ctx, r.db, bidID)
+}
+
+func getBid(ctx context.Context, db sqlx.QueryerContext, bidID string) (model.Bid, error) {
+	q := `SELECT b.id, bv.name, b.status, b.author_type, b.author_id, b.lot_id, bv.price, bv.currency, bv.version, b.created_at
 		FROM bid_version bv
 			INNER JOIN bid b ON bv.bid_id = b.id
 		WHERE bid_id = $1
     		AND bv.version = (SELECT MAX(version) FROM bid_version WHERE bid_id = $1);`
 
 	var bid model.Bid
-	if err := r.db.GetContext(ctx, &bid, q, bidID); err != nil {
+	if err := sqlx.GetContext(ctx, db, &bid, q, bidID); err != nil {
 		return model.Bid{}, model.ErrNoBidFound
 	}
 	return bid, nil
 }
 
+// finishBid records the change of the bid made in tx and commits it.
+func finishBid(ctx context.Context, tx *sqlx.Tx, action string, before *model.Bid, bidID string) (model.Bid, error) {
+	after, err := getBid(ctx, tx, bidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	e := auditEvent{
+		EntityType: AuditEntityBid,
+		EntityID:   bidID,
+		Action:     action,
+		After:      after,
+	}
+	if before != nil {
+		e.Before = before
+	}
+	if err = writeAudit(ctx, tx, e); err != nil {
+		return model.Bid{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Bid{}, model.ErrInternal
+	}
+	return after, nil
+}
+
 type CreateBidInput struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	TenderID    string `json:"tenderId"`
-	AuthorType  string `json:"authorType"`
-	AuthorID    string `json:"authorId"`
+	Name        string        `json:"name" validate:"required,max=100"`
+	Description string        `json:"description" validate:"required,max=500"`
+	Price       *model.Amount `json:"price"`
+	Currency    *string       `json:"currency"`
+	TenderID    string        `json:"tenderId" validate:"required,uuid"`
+	LotID       *string       `json:"lotId" validate:"uuid"`
+	AuthorType  string        `json:"authorType" validate:"required,oneof=Organization User"`
+	// AuthorID is accepted as in the API contract, but is always replaced
+	// with the authenticated caller.
+	AuthorID string `json:"authorId" validate:"uuid"`
 }
 
 func (r *Repository) CreateBid(ctx context.Context, input CreateBidInput) (model.Bid, error) {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return model.Bid{}, model.ErrInternal
 	}
@@ -42,40 +77,46 @@ func (r *Repository) CreateBid(ctx context.Context, input CreateBidInput) (model
 		}
 	}()
 
-	q := `INSERT INTO bid (tender_id, author_type, author_id)
-			VALUES ($1, $2, $3)
+	if input.Price != nil {
+		if err = offerAuctionPrice(ctx, tx, input.TenderID, input.LotID, *input.Price); err != nil {
+			return model.Bid{}, err
+		}
+	}
+
+	q := `INSERT INTO bid (tender_id, lot_id, author_type, author_id)
+			VALUES ($1, $2, $3, $4)
 			RETURNING id;`
 
 	var bidID string
-	if err = tx.GetContext(ctx, &bidID, q, input.TenderID, input.AuthorType, input.AuthorID); err != nil {
+	if err = tx.GetContext(ctx, &bidID, q, input.TenderID, input.LotID, input.AuthorType, input.AuthorID); err != nil {
 		logger.Error(ctx, err.Error())
 		return model.Bid{}, model.ErrInternal
 	}
 
-	q = `INSERT INTO bid_version (bid_id, name, description, version)
-			SELECT b.id, $1, $2, COALESCE(bv.version, 0) + 1
+	q = `INSERT INTO bid_version (bid_id, name, description, price, currency, version)
+			SELECT b.id, $1, $2, $4, $5, COALESCE(bv.version, 0) + 1
 			FROM bid b
 				LEFT JOIN bid_version bv ON b.id = bv.bid_id
 			WHERE b.id = $3
 			ORDER BY version DESC
 			LIMIT 1;`
 
-	if _, err = tx.ExecContext(ctx, q, input.Name, input.Description, bidID); err != nil {
+	_, err = tx.ExecContext(ctx, q, input.Name, input.Description, bidID, numericArg(input.Price), input.Currency)
+	if err != nil {
 		logger.Error(ctx, err.Error())
 		return model.Bid{}, model.ErrInternal
 	}
-	_ = tx.Commit()
-	return r.GetBidByID(ctx, bidID)
+	bid, err := finishBid(ctx, tx, _auditActionCreate, nil, bidID)
+	return bid, err
 }
 
 type GetMyBidsInput struct {
-	Limit  int
-	Offset int
 	UserID string
+	Pagination
 }
 
-func (r *Repository) GetMyBids(ctx context.Context, input GetMyBidsInput) ([]model.Bid, error) {
-	q := `SELECT b.id, bv.name, b.status, b.author_type, b.author_id, bv.version, b.created_at
+func (r *Repository) GetMyBids(ctx context.Context, input GetMyBidsInput) (model.Page[model.Bid], error) {
+	q := `SELECT b.id, bv.name, b.status, b.author_type, b.author_id, b.lot_id, bv.price, bv.currency, bv.version, b.created_at
 			FROM bid b
 				 INNER JOIN bid_version bv
 					ON b.id = bv.bid_id
@@ -84,17 +125,15 @@ func (r *Repository) GetMyBids(ctx context.Context, input GetMyBidsInput) ([]mod
 							FROM bid_version
 							WHERE bid_id = b.id
 						)
-			WHERE b.author_id = $1
-			ORDER BY bv.name
-			LIMIT $2
-			OFFSET $3;`
-
-	bids := make([]model.Bid, 0)
-	if err := r.db.SelectContext(ctx, &bids, q, input.UserID, input.Limit, input.Offset); err != nil {
-		logger.Error(ctx, err.Error())
-		return nil, model.ErrInternal
-	}
-	return bids, nil
+			WHERE b.author_id = $1`
+
+	return selectPage(ctx, r.db, listQuery{
+		query: q,
+		args:  []any{input.UserID},
+		order: _bidOrders[BidSortName],
+		name:  "bv.name",
+		id:    "b.id",
+	}, input.Pagination, bidCursor)
 }
 
 func (r *Repository) BidExists(ctx context.Context, bidID string) bool {
@@ -108,15 +147,41 @@ func (r *Repository) BidExists(ctx context.Context, bidID string) bool {
 	return foundBidID == bidID
 }
 
+const (
+	BidSortName      = "name"
+	BidSortPrice     = "price"
+	BidSortPriceDesc = "-price"
+)
+
+// _bidOrders maps accepted sort values to ORDER BY clauses. Prices in
+// different currencies are not converted; bids without price go last.
+var _bidOrders = map[string]string{
+	BidSortName:      "bv.name, b.id",
+	BidSortPrice:     "bv.price ASC NULLS LAST, bv.currency, bv.name, b.id",
+	BidSortPriceDesc: "bv.price DESC NULLS LAST, bv.currency, bv.name, b.id",
+}
+
+// BidSorts returns the accepted sort values.
+func BidSorts() []string {
+	return []string{BidSortName, BidSortPrice, BidSortPriceDesc}
+}
+
+func IsBidSort(sort string) bool {
+	_, ok := _bidOrders[sort]
+	return ok
+}
+
 type GetTenderBidsInput struct {
 	TenderID string
-	Username string
-	Limit    int
-	Offset   int
+	// LotID limits the bids to one lot of the tender if set.
+	LotID string
+	// Sort orders offset pages; keyset pages are always sorted by name.
+	Sort string
+	Pagination
 }
 
-func (r *Repository) GetTenderBids(ctx context.Context, input GetTenderBidsInput) ([]model.Bid, error) {
-	q := `SELECT b.id, bv.name, b.status, b.author_type, b.author_id, bv.version, b.created_at
+func (r *Repository) GetTenderBids(ctx context.Context, input GetTenderBidsInput) (model.Page[model.Bid], error) {
+	q := `SELECT b.id, bv.name, b.status, b.author_type, b.author_id, b.lot_id, bv.price, bv.currency, bv.version, b.created_at
 			FROM bid b
 				INNER JOIN bid_version bv
 					ON b.id = bv.bid_id
@@ -126,15 +191,45 @@ func (r *Repository) GetTenderBids(ctx context.Context, input GetTenderBidsInput
 							WHERE bid_id = b.id
 						)
 			WHERE b.tender_id = $1
-			ORDER BY bv.name
-			LIMIT $2
-			OFFSET $3;`
+				AND (NULLIF($2, '') IS NULL OR b.lot_id = NULLIF($2, '')::uuid)`
 
-	bids := make([]model.Bid, 0)
-	if err := r.db.SelectContext(ctx, &bids, q, input.TenderID, input.Limit, input.Offset); err != nil {
-		return nil, model.ErrNoBidsFound
+	order, ok := _bidOrders[input.Sort]
+	if !ok {
+		order = _bidOrders[BidSortName]
+	}
+	page, err := selectPage(ctx, r.db, listQuery{
+		query: q,
+		args:  []any{input.TenderID, input.LotID},
+		order: order,
+		name:  "bv.name",
+		id:    "b.id",
+	}, input.Pagination, bidCursor)
+	if err != nil {
+		return model.Page[model.Bid]{}, model.ErrNoBidsFound
 	}
-	return bids, nil
+	return page, nil
+}
+
+type CountTenderBidsInput struct {
+	TenderID string
+	// LotID limits the count to one lot of the tender if set.
+	LotID string
+}
+
+// CountTenderBids counts bids of the tender that are not canceled.
+func (r *Repository) CountTenderBids(ctx context.Context, input CountTenderBidsInput) (int, error) {
+	q := `SELECT COUNT(*)
+			FROM bid
+			WHERE tender_id = $1
+				AND (NULLIF($2, '') IS NULL OR lot_id = NULLIF($2, '')::uuid)
+				AND status <> 'Canceled';`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, q, input.TenderID, input.LotID); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+	return count, nil
 }
 
 func (r *Repository) GetBidStatus(ctx context.Context, bidID string) (string, error) {
@@ -148,56 +243,182 @@ func (r *Repository) GetBidStatus(ctx context.Context, bidID string) (string, er
 }
 
 type UpdateBidStatusInput struct {
-	BidID  string
-	Status string
+	BidID            string
+	FromStatus       string
+	Status           string
+	ExpectedVersions []int
 }
 
+// UpdateBidStatus moves the bid from FromStatus to Status. The change is
+// refused with ErrIllegalTransition if the status was changed concurrently.
+// Publishing a draft offers its price to a running auction, as a new price
+// would be offered.
 func (r *Repository) UpdateBidStatus(ctx context.Context, input UpdateBidStatusInput) (model.Bid, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Bid{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var offered *model.Amount
+	if input.FromStatus == model.BidStatusCreated && input.Status == model.BidStatusPublished {
+		// the tender is locked before the bid, in the order DecideBid takes them
+		if offered, err = offerDraftPrice(ctx, tx, input.BidID); err != nil {
+			return model.Bid{}, err
+		}
+	}
+	before, err := lockBid(ctx, tx, input.BidID, input.ExpectedVersions)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if before.Status != input.FromStatus {
+		err = model.ErrIllegalTransition
+		return model.Bid{}, err
+	}
+	if offered != nil && (before.Price == nil || *before.Price != *offered) {
+		// the price was edited before the tender was locked
+		err = model.ErrVersionMismatch
+		return model.Bid{}, err
+	}
+
 	q := `UPDATE bid
 			SET status = $1
 			WHERE id = $2;`
 
-	if _, err := r.db.ExecContext(ctx, q, input.Status, input.BidID); err != nil {
+	if _, err = tx.ExecContext(ctx, q, input.Status, input.BidID); err != nil {
 		return model.Bid{}, model.ErrInvalidAttributeValue
 	}
-	return r.GetBidByID(ctx, input.BidID)
+	if err = bumpVersion(ctx, tx, _bidVersions, _bidAttachments, input.BidID); err != nil {
+		return model.Bid{}, err
+	}
+	bid, err := finishBid(ctx, tx, _auditActionStatus, &before, input.BidID)
+	return bid, err
+}
+
+// cancelOpenBids cancels every created or published bid of the tender, or only
+// of its lot if lotID is set, except the winner one. Each cancellation is
+// recorded to audit.
+func cancelOpenBids(ctx context.Context, tx *sqlx.Tx, tenderID, lotID, winnerBidID string) error {
+	q := `SELECT id
+			FROM bid
+			WHERE tender_id = $1
+				AND (NULLIF($2, '') IS NULL OR lot_id = NULLIF($2, '')::uuid)
+				AND status IN ('Created', 'Published')
+				AND id IS DISTINCT FROM NULLIF($3, '')::uuid
+			ORDER BY id
+			FOR UPDATE;`
+
+	var bidIDs []string
+	if err := tx.SelectContext(ctx, &bidIDs, q, tenderID, lotID, winnerBidID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+
+	for _, bidID := range bidIDs {
+		if err := cancelBid(ctx, tx, bidID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// cancelBid cancels the locked bid and records it to audit.
+func cancelBid(ctx context.Context, tx *sqlx.Tx, bidID string) error {
+	before, err := getBid(ctx, tx, bidID)
+	if err != nil {
+		return err
+	}
+
+	q := `UPDATE bid
+			SET status = 'Canceled'
+			WHERE id = $1;`
+
+	if _, err = tx.ExecContext(ctx, q, bidID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	if err = bumpVersion(ctx, tx, _bidVersions, _bidAttachments, bidID); err != nil {
+		return err
+	}
+	after, err := getBid(ctx, tx, bidID)
+	if err != nil {
+		return err
+	}
+	return writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityBid,
+		EntityID:   bidID,
+		Action:     _auditActionStatus,
+		Before:     before,
+		After:      after,
+	})
+}
+
+func (r *Repository) GetBidTenderID(ctx context.Context, bidID string) (string, error) {
+	q := `SELECT tender_id FROM bid WHERE id = $1;`
+
+	var tenderID string
+	if err := r.db.GetContext(ctx, &tenderID, q, bidID); err != nil {
+		return "", model.ErrNoBidFound
+	}
+	return tenderID, nil
 }
 
 type EditBidInput struct {
 	BidID       string
-	Name        string
-	Description string
+	Name        model.Optional[string]
+	Description model.Optional[string]
+	// Price and Currency are changed together.
+	Price            model.Optional[model.Amount]
+	Currency         model.Optional[string]
+	ExpectedVersions []int
 }
 
-func (r *Repository) UpdateBid(ctx context.Context, input EditBidInput) (model.Bid, error) {
-	q := `INSERT INTO bid_version (bid_id, name, description, version)
-			SELECT b.id, %s, %s, COALESCE(bv.version, 0) + 1
-			FROM bid b
-				LEFT JOIN bid_version bv ON b.id = bv.bid_id
-			WHERE b.id = $1
-			ORDER BY version DESC
-			LIMIT 1;`
+// bidEdit is the version copy made by the partial update.
+func bidEdit(input EditBidInput) *versionCopy {
+	c := newVersionCopy(_bidVersions)
+	setOptional(c, "name", input.Name)
+	setOptional(c, "description", input.Description)
+	if input.Price.Set {
+		c.set("price", numericArg(input.Price.Ptr()))
+	}
+	setOptional(c, "currency", input.Currency)
+	return c
+}
 
-	format := "'%s'"
+func (r *Repository) UpdateBid(ctx context.Context, input EditBidInput) (model.Bid, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Bid{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
 
-	var name = "name"
-	if input.Name != "" {
-		name = fmt.Sprintf(format, input.Name)
+	if price := input.Price.Ptr(); price != nil {
+		// the tender is locked before the bid, in the order DecideBid takes them
+		if err = offerBidPrice(ctx, tx, input.BidID, *price); err != nil {
+			return model.Bid{}, err
+		}
 	}
-	var description = "description"
-	if input.Description != "" {
-		description = fmt.Sprintf(format, input.Description)
+	before, err := lockBid(ctx, tx, input.BidID, input.ExpectedVersions)
+	if err != nil {
+		return model.Bid{}, err
 	}
-	q = fmt.Sprintf(q, name, description)
 
-	if _, err := r.db.ExecContext(ctx, q, input.BidID); err != nil {
-		if strings.Contains(err.Error(), "invalid value") {
-			return model.Bid{}, model.ErrInvalidAttributeValue
-		}
-		logger.Error(ctx, err.Error())
-		return model.Bid{}, model.ErrInternal
+	if err = bidEdit(input).exec(ctx, tx, input.BidID); err != nil {
+		return model.Bid{}, err
 	}
-	return r.GetBidByID(ctx, input.BidID)
+	if err = carryAttachments(ctx, tx, _bidAttachments, input.BidID); err != nil {
+		return model.Bid{}, err
+	}
+	bid, err := finishBid(ctx, tx, _auditActionEdit, &before, input.BidID)
+	return bid, err
 }
 
 type BidHasVersionInput struct {
@@ -221,22 +442,106 @@ func (r *Repository) BidHasVersion(ctx context.Context, input BidHasVersionInput
 }
 
 type RollbackBidInput struct {
-	BidID   string
-	Version int
+	BidID            string
+	Version          int
+	ExpectedVersions []int
 }
 
 func (r *Repository) RollbackBid(ctx context.Context, input RollbackBidInput) (model.Bid, error) {
-	q := `INSERT INTO bid_version (bid_id, name, description, version)
-			SELECT b.id, bv.name, bv.description, COALESCE(
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Bid{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockBid(ctx, tx, input.BidID, input.ExpectedVersions)
+	if err != nil {
+		return model.Bid{}, err
+	}
+
+	q := `INSERT INTO bid_version (bid_id, name, description, price, currency, version)
+			SELECT b.id, bv.name, bv.description, bv.price, bv.currency, COALESCE(
 				(SELECT MAX(version) FROM bid_version WHERE bid_id = $1), 0
 			) + 1
 			FROM bid b
 				LEFT JOIN bid_version bv ON b.id = bv.bid_id
 			WHERE b.id = $1 AND bv.version = $2;`
 
-	if _, err := r.db.ExecContext(ctx, q, input.BidID, input.Version); err != nil {
+	if _, err = tx.ExecContext(ctx, q, input.BidID, input.Version); err != nil {
+		if isUniqueViolation(err) {
+			return model.Bid{}, model.ErrVersionMismatch
+		}
 		logger.Error(ctx, err.Error())
 		return model.Bid{}, model.ErrInternal
 	}
-	return r.GetBidByID(ctx, input.BidID)
+	if err = restoreAttachments(ctx, tx, _bidAttachments, input.BidID, input.Version); err != nil {
+		return model.Bid{}, err
+	}
+	bid, err := finishBid(ctx, tx, _auditActionRollback, &before, input.BidID)
+	return bid, err
+}
+
+func (r *Repository) GetBidVersions(ctx context.Context, bidID string) ([]model.BidVersion, error) {
+	q := `SELECT version, name, description, price, currency, created_at
+			FROM bid_version
+			WHERE bid_id = $1
+			ORDER BY version;`
+
+	versions := make([]model.BidVersion, 0)
+	if err := r.db.SelectContext(ctx, &versions, q, bidID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return versions, nil
+}
+
+type GetBidVersionInput struct {
+	BidID   string
+	Version int
+}
+
+func (r *Repository) GetBidVersion(ctx context.Context, input GetBidVersionInput) (model.BidVersion, error) {
+	q := `SELECT version, name, description, price, currency, created_at
+			FROM bid_version
+			WHERE bid_id = $1 AND version = $2;`
+
+	var version model.BidVersion
+	if err := r.db.GetContext(ctx, &version, q, input.BidID, input.Version); err != nil {
+		return model.BidVersion{}, model.ErrNoSuchVersion
+	}
+	return version, nil
+}
+
+// copyBidVersion adds a version of the bid with the fields of the latest one.
+func copyBidVersion(ctx context.Context, tx *sqlx.Tx, bidID string) error {
+	return newVersionCopy(_bidVersions).exec(ctx, tx, bidID)
+}
+
+// lockBid locks the bid row until the end of the transaction, checks that its
+// latest version is one of expectedVersions and returns the bid as it was
+// before the change. No expectedVersions skips the check.
+func lockBid(ctx context.Context, tx *sqlx.Tx, bidID string, expectedVersions []int) (model.Bid, error) {
+	q := `SELECT id FROM bid WHERE id = $1 FOR UPDATE;`
+
+	var lockedID string
+	if err := tx.GetContext(ctx, &lockedID, q, bidID); err != nil {
+		return model.Bid{}, model.ErrNoBidFound
+	}
+	if len(expectedVersions) > 0 {
+		q = `SELECT COALESCE(MAX(version), 0) FROM bid_version WHERE bid_id = $1;`
+
+		var version int
+		if err := tx.GetContext(ctx, &version, q, bidID); err != nil {
+			logger.Error(ctx, err.Error())
+			return model.Bid{}, model.ErrInternal
+		}
+		if !slices.Contains(expectedVersions, version) {
+			return model.Bid{}, model.ErrVersionMismatch
+		}
+	}
+	return getBid(ctx, tx, bidID)
 }
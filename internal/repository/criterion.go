@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+func getCriteria(ctx context.Context, db sqlx.QueryerContext, tenderID string) ([]model.Criterion, error) {
+	q := `SELECT id, name, weight
+			FROM tender_criterion
+			WHERE tender_id = $1
+			ORDER BY position;`
+
+	criteria := make([]model.Criterion, 0)
+	if err := sqlx.SelectContext(ctx, db, &criteria, q, tenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return criteria, nil
+}
+
+func (r *Repository) GetTenderCriteria(ctx context.Context, tenderID string) ([]model.Criterion, error) {
+	return getCriteria(ctx, r.db, tenderID)
+}
+
+type SetTenderCriteriaInput struct {
+	TenderID string
+	Criteria []model.Criterion
+}
+
+// SetTenderCriteria replaces the criteria of the tender. Criteria are fixed
+// once any bid has been scored, so the totals stay comparable.
+func (r *Repository) SetTenderCriteria(ctx context.Context, input SetTenderCriteriaInput) ([]model.Criterion, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = lockTender(ctx, tx, input.TenderID, nil); err != nil {
+		return nil, err
+	}
+
+	q := `SELECT EXISTS (
+			SELECT 1
+			FROM bid_score s
+				INNER JOIN tender_criterion c ON s.criterion_id = c.id
+			WHERE c.tender_id = $1
+		);`
+
+	var scored bool
+	if err = tx.GetContext(ctx, &scored, q, input.TenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	if scored {
+		err = model.ErrCriteriaLocked
+		return nil, err
+	}
+
+	before, err := getCriteria(ctx, tx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	q = `DELETE FROM tender_criterion WHERE tender_id = $1;`
+
+	if _, err = tx.ExecContext(ctx, q, input.TenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+
+	q = `INSERT INTO tender_criterion (tender_id, name, weight, position)
+			VALUES ($1, $2, $3, $4);`
+
+	for i, c := range input.Criteria {
+		if _, err = tx.ExecContext(ctx, q, input.TenderID, c.Name, c.Weight, i); err != nil {
+			if isUniqueViolation(err) || isCheckViolation(err) {
+				return nil, model.ErrInvalidAttributeValue
+			}
+			logger.Error(ctx, err.Error())
+			return nil, model.ErrInternal
+		}
+	}
+
+	after, err := getCriteria(ctx, tx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   input.TenderID,
+		Action:     _auditActionCriteria,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return after, nil
+}
+
+func getAuthorScores(ctx context.Context, db sqlx.QueryerContext, bidID, authorID string) ([]model.BidScore, error) {
+	q := `SELECT s.criterion_id, s.score
+			FROM bid_score s
+				INNER JOIN tender_criterion c ON s.criterion_id = c.id
+			WHERE s.bid_id = $1 AND s.author_id = $2
+			ORDER BY c.position;`
+
+	scores := make([]model.BidScore, 0)
+	if err := sqlx.SelectContext(ctx, db, &scores, q, bidID, authorID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return scores, nil
+}
+
+type SetBidScoresInput struct {
+	BidID    string
+	AuthorID string
+	Scores   []model.BidScore
+}
+
+// SetBidScores stores the author's scores for the bid, replacing earlier scores
+// on the same criteria, and returns every score of the author for the bid.
+func (r *Repository) SetBidScores(ctx context.Context, input SetBidScoresInput) ([]model.BidScore, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// the shared lock on the tender keeps its criteria from being replaced meanwhile
+	q := `SELECT t.id
+			FROM tender t
+				INNER JOIN bid b ON b.tender_id = t.id
+			WHERE b.id = $1
+			FOR SHARE OF t;`
+
+	var tenderID string
+	if err = tx.GetContext(ctx, &tenderID, q, input.BidID); err != nil {
+		return nil, model.ErrNoBidFound
+	}
+
+	criterionIDs := make([]string, 0, len(input.Scores))
+	for _, s := range input.Scores {
+		criterionIDs = append(criterionIDs, s.CriterionID)
+	}
+
+	q = `SELECT COUNT(*)
+			FROM tender_criterion
+			WHERE tender_id = $1 AND id = ANY($2::uuid[]);`
+
+	var known int
+	if err = tx.GetContext(ctx, &known, q, tenderID, criterionIDs); err != nil {
+		if isInvalidInput(err) {
+			// a malformed ID cannot name a criterion of the tender
+			err = model.ErrInvalidAttributeValue.Wrap("criterion_not_in_tender")
+			return nil, err
+		}
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	if known != len(criterionIDs) {
+		err = model.ErrInvalidAttributeValue.Wrap("criterion_not_in_tender")
+		return nil, err
+	}
+
+	before, err := getAuthorScores(ctx, tx, input.BidID, input.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	q = `INSERT INTO bid_score (bid_id, criterion_id, author_id, score)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (bid_id, criterion_id, author_id)
+				DO UPDATE SET score = EXCLUDED.score, updated_at = CURRENT_TIMESTAMP;`
+
+	for _, s := range input.Scores {
+		if _, err = tx.ExecContext(ctx, q, input.BidID, s.CriterionID, input.AuthorID, s.Score); err != nil {
+			if isCheckViolation(err) {
+				return nil, model.ErrInvalidAttributeValue
+			}
+			logger.Error(ctx, err.Error())
+			return nil, model.ErrInternal
+		}
+	}
+
+	after, err := getAuthorScores(ctx, tx, input.BidID, input.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityBid,
+		EntityID:   input.BidID,
+		Action:     _auditActionScore,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return after, nil
+}
+
+// GetTenderScores returns per-criterion averages for every scored bid of the tender.
+func (r *Repository) GetTenderScores(ctx context.Context, tenderID string) ([]model.CriterionScore, error) {
+	q := `SELECT s.bid_id, s.criterion_id, AVG(s.score)::float8 AS average, COUNT(*) AS scorers
+			FROM bid_score s
+				INNER JOIN tender_criterion c ON s.criterion_id = c.id
+			WHERE c.tender_id = $1
+			GROUP BY s.bid_id, s.criterion_id;`
+
+	scores := make([]model.CriterionScore, 0)
+	if err := r.db.SelectContext(ctx, &scores, q, tenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return scores, nil
+}
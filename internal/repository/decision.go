@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+type DecideBidInput struct {
+	TenderID     string
+	BidID        string
+	UserID       string
+	Decision     string
+	DeciderRoles []string
+}
+
+type decisionState struct {
+	UserID   string `db:"user_id" json:"userId"`
+	Decision string `db:"decision" json:"decision"`
+}
+
+// DecideBid records the decision of the user on the bid. A rejection cancels
+// the bid; the approval that reaches the quorum awards it. The tender and the
+// bid stay locked until the tally is acted upon, so concurrent decisions are
+// counted one after another and the bid is awarded at most once.
+func (r *Repository) DecideBid(ctx context.Context, input DecideBidInput) (model.BidDecisionResult, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.BidDecisionResult{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// the tender is locked before the bid, in the order awardBid needs them
+	tender, err := lockTender(ctx, tx, input.TenderID, nil)
+	if err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	bid, err := lockBid(ctx, tx, input.BidID, nil)
+	if err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	awarded, err := isAwarded(ctx, tx, input.TenderID, bid.LotID)
+	if err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	if err = checkDecidable(bid, awarded); err != nil {
+		return model.BidDecisionResult{}, err
+	}
+
+	prev, err := getDecisionTally(ctx, tx, input.BidID, input.DeciderRoles)
+	if err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	if err = writeDecision(ctx, tx, input); err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	if input.Decision == "Rejected" {
+		// a single rejection is enough to decline the bid
+		if err = cancelBid(ctx, tx, input.BidID); err != nil {
+			return model.BidDecisionResult{}, err
+		}
+	}
+	tally, err := getDecisionTally(ctx, tx, input.BidID, input.DeciderRoles)
+	if err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	if input.Decision == "Approved" && !prev.Reached() && tally.Reached() {
+		// the winner stays open, the rest of the tender's or the lot's bids are canceled
+		if err = awardBid(ctx, tx, tender, input.BidID); err != nil {
+			return model.BidDecisionResult{}, err
+		}
+	}
+	if bid, err = getBid(ctx, tx, input.BidID); err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.BidDecisionResult{}, model.ErrInternal
+	}
+	return model.BidDecisionResult{
+		Bid:       bid,
+		Decisions: tally,
+	}, nil
+}
+
+// checkDecidable refuses decisions on a canceled bid and on every bid of a
+// tender or lot that already has a winner. The winner is the only bid left
+// open there, and a late rejection would cancel it.
+func checkDecidable(bid model.Bid, awarded bool) error {
+	switch {
+	case bid.Status == model.BidStatusCanceled:
+		return model.ErrNoRights.Wrap("bid_rejected")
+	case awarded:
+		return model.ErrBidDecided
+	}
+	return nil
+}
+
+// isAwarded reports whether the lot, if set, or else the tender already has a
+// winner. A tender closed by the deadline has none until a bid is awarded, so
+// the award is looked up in audit rather than inferred from the status.
+func isAwarded(ctx context.Context, tx *sqlx.Tx, tenderID string, lotID *string) (bool, error) {
+	if lotID != nil {
+		lot, err := getLot(ctx, tx, *lotID)
+		if err != nil {
+			return false, err
+		}
+		return lot.Status == model.LotStatusAwarded, nil
+	}
+
+	q := `SELECT EXISTS (
+				SELECT 1
+				FROM audit_event
+				WHERE entity_type = $1 AND entity_id = $2 AND action = $3
+			);`
+
+	var awarded bool
+	if err := tx.GetContext(ctx, &awarded, q, AuditEntityTender, tenderID, _auditActionAward); err != nil {
+		logger.Error(ctx, err.Error())
+		return false, model.ErrInternal
+	}
+	return awarded, nil
+}
+
+// writeDecision upserts the decision of the user and records it to audit.
+func writeDecision(ctx context.Context, tx *sqlx.Tx, input DecideBidInput) error {
+	q := `SELECT user_id, decision
+			FROM bid_decision
+			WHERE bid_id = $1 AND user_id = $2
+			FOR UPDATE;`
+
+	var before *decisionState
+	var prev decisionState
+	switch err := tx.GetContext(ctx, &prev, q, input.BidID, input.UserID); {
+	case err == nil:
+		before = &prev
+	case errors.Is(err, sql.ErrNoRows):
+	default:
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+
+	q = `INSERT INTO bid_decision (bid_id, user_id, decision)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (bid_id, user_id)
+				DO UPDATE SET decision = EXCLUDED.decision, created_at = CURRENT_TIMESTAMP;`
+
+	if _, err := tx.ExecContext(ctx, q, input.BidID, input.UserID, input.Decision); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+
+	e := auditEvent{
+		EntityType: AuditEntityBid,
+		EntityID:   input.BidID,
+		Action:     _auditActionDecision,
+		After:      decisionState{UserID: input.UserID, Decision: input.Decision},
+	}
+	if before != nil {
+		e.Before = before
+	}
+	return writeAudit(ctx, tx, e)
+}
+
+// getDecisionTally counts decisions submitted for the bid. Quorum equals
+// min(3, number of responsibles of the tender's organization whose role allows deciding).
+func getDecisionTally(ctx context.Context, tx *sqlx.Tx, bidID string, deciderRoles []string) (model.DecisionTally, error) {
+	q := `SELECT
+			COUNT(*) FILTER (WHERE d.decision = 'Approved') AS approved,
+			COUNT(*) FILTER (WHERE d.decision = 'Rejected') AS rejected,
+			LEAST(3, (
+				SELECT COUNT(*)
+				FROM bid b
+					INNER JOIN tender t ON b.tender_id = t.id
+					INNER JOIN organization_responsible r ON t.organization_id = r.organization_id
+				WHERE b.id = $1 AND r.role::text = ANY($2::text[])
+			)) AS quorum
+		FROM bid_decision d
+		WHERE d.bid_id = $1;`
+
+	var tally model.DecisionTally
+	if err := tx.GetContext(ctx, &tally, q, bidID, deciderRoles); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.DecisionTally{}, model.ErrInternal
+	}
+	return tally, nil
+}
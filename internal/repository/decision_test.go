@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+func TestCheckDecidable(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  string
+		awarded bool
+		err     error
+	}{
+		{name: "open", status: model.BidStatusPublished},
+		{name: "draft", status: model.BidStatusCreated},
+		{name: "canceled", status: model.BidStatusCanceled, err: model.ErrNoRights},
+		// a late rejection must not cancel the winner, the only bid left open
+		{name: "winner", status: model.BidStatusPublished, awarded: true, err: model.ErrBidDecided},
+		{name: "canceled after award", status: model.BidStatusCanceled, awarded: true, err: model.ErrNoRights},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkDecidable(model.Bid{Status: tt.status}, tt.awarded)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+// tenderAdmits returns a condition on the tender aliased as t that holds when
+// the viewer, whose user and organization IDs are passed as parameters userArg
+// and orgArg, belongs to the tender organization or is invited to the tender,
+// either personally or with an organization. Empty IDs of an anonymous viewer
+// become NULL and match nothing.
+func tenderAdmits(t string, userArg, orgArg int) string {
+	return fmt.Sprintf(`(%[1]s.organization_id = NULLIF($%[3]d, '')::uuid
+				OR EXISTS (
+					SELECT 1
+					FROM organization_responsible r
+					WHERE r.organization_id = %[1]s.organization_id AND r.user_id = NULLIF($%[2]d, '')::uuid
+				)
+				OR EXISTS (
+					SELECT 1
+					FROM tender_invitation i
+					WHERE i.tender_id = %[1]s.id
+						AND (i.user_id = NULLIF($%[2]d, '')::uuid
+							OR i.organization_id = NULLIF($%[3]d, '')::uuid
+							OR i.organization_id IN (
+								SELECT organization_id FROM organization_responsible WHERE user_id = NULLIF($%[2]d, '')::uuid
+							))
+				))`, t, userArg, orgArg)
+}
+
+type TenderAdmitsInput struct {
+	TenderID       string
+	UserID         string
+	OrganizationID string
+}
+
+// TenderAdmits reports whether the viewer may see the tender: public tenders
+// admit everyone, private ones only their organization and invitees.
+func (r *Repository) TenderAdmits(ctx context.Context, input TenderAdmitsInput) (bool, error) {
+	q := fmt.Sprintf(`SELECT NOT t.private OR %s
+			FROM tender t
+			WHERE t.id = $1;`, tenderAdmits("t", 2, 3))
+
+	var admitted bool
+	if err := r.db.GetContext(ctx, &admitted, q, input.TenderID, input.UserID, input.OrganizationID); err != nil {
+		return false, model.ErrTenderNotFound
+	}
+	return admitted, nil
+}
+
+func getInvitations(ctx context.Context, db sqlx.QueryerContext, tenderID string) ([]model.Invitation, error) {
+	q := `SELECT i.id, i.organization_id, e.username, i.created_at
+			FROM tender_invitation i
+				LEFT JOIN employee e ON i.user_id = e.id
+			WHERE i.tender_id = $1
+			ORDER BY i.created_at, i.id;`
+
+	invitations := make([]model.Invitation, 0)
+	if err := sqlx.SelectContext(ctx, db, &invitations, q, tenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return invitations, nil
+}
+
+func (r *Repository) GetTenderInvitations(ctx context.Context, tenderID string) ([]model.Invitation, error) {
+	return getInvitations(ctx, r.db, tenderID)
+}
+
+type CreateInvitationInput struct {
+	TenderID string
+	// Exactly one of OrganizationID and UserID is set.
+	OrganizationID string
+	UserID         string
+}
+
+func (r *Repository) CreateInvitation(ctx context.Context, input CreateInvitationInput) (model.Invitation, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Invitation{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = lockTender(ctx, tx, input.TenderID, nil); err != nil {
+		return model.Invitation{}, err
+	}
+	before, err := getInvitations(ctx, tx, input.TenderID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+
+	q := `INSERT INTO tender_invitation (tender_id, organization_id, user_id)
+			VALUES ($1, NULLIF($2, '')::uuid, NULLIF($3, '')::uuid)
+			RETURNING id;`
+
+	var invitationID string
+	if err = tx.GetContext(ctx, &invitationID, q, input.TenderID, input.OrganizationID, input.UserID); err != nil {
+		switch {
+		case isUniqueViolation(err):
+			err = model.ErrInvalidAttributeValue.Wrap("invitation_exists")
+			return model.Invitation{}, err
+		case isForeignKeyViolation(err), isInvalidInput(err):
+			err = model.ErrInvalidAttributeValue.Wrap("organization_not_found")
+			return model.Invitation{}, err
+		}
+		logger.Error(ctx, err.Error())
+		return model.Invitation{}, model.ErrInternal
+	}
+
+	after, err := getInvitations(ctx, tx, input.TenderID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   input.TenderID,
+		Action:     _auditActionInvite,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return model.Invitation{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Invitation{}, model.ErrInternal
+	}
+	for _, invitation := range after {
+		if invitation.ID == invitationID {
+			return invitation, nil
+		}
+	}
+	return model.Invitation{}, model.ErrInternal
+}
+
+type RemoveInvitationInput struct {
+	TenderID     string
+	InvitationID string
+}
+
+func (r *Repository) RemoveInvitation(ctx context.Context, input RemoveInvitationInput) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = lockTender(ctx, tx, input.TenderID, nil); err != nil {
+		return err
+	}
+	before, err := getInvitations(ctx, tx, input.TenderID)
+	if err != nil {
+		return err
+	}
+
+	q := `DELETE FROM tender_invitation WHERE id = $1 AND tender_id = $2;`
+
+	res, err := tx.ExecContext(ctx, q, input.InvitationID, input.TenderID)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		err = model.ErrInvitationNotFound
+		return err
+	}
+
+	after, err := getInvitations(ctx, tx, input.TenderID)
+	if err != nil {
+		return err
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   input.TenderID,
+		Action:     _auditActionUninvite,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
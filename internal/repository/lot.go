@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+func (r *Repository) GetLotByID(ctx context.Context, lotID string) (model.Lot, error) {
+	return getLot(ctx, r.db, lotID)
+}
+
+func getLot(ctx context.Context, db sqlx.QueryerContext, lotID string) (model.Lot, error) {
+	q := `SELECT l.id, l.tender_id, lv.name, lv.description, lv.service_type, l.status, l.winner_bid_id, lv.version, l.created_at
+		FROM lot_version lv
+			INNER JOIN lot l ON lv.lot_id = l.id
+		WHERE lot_id = $1
+			AND version = (SELECT MAX(version) FROM lot_version WHERE lot_id = $1);`
+
+	var lot model.Lot
+	if err := sqlx.GetContext(ctx, db, &lot, q, lotID); err != nil {
+		return model.Lot{}, model.ErrLotNotFound
+	}
+	return lot, nil
+}
+
+// finishLot records the change of the lot made in tx to the tender audit and commits it.
+func finishLot(ctx context.Context, tx *sqlx.Tx, action string, before *model.Lot, lotID string) (model.Lot, error) {
+	after, err := getLot(ctx, tx, lotID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+	if err = writeLotAudit(ctx, tx, action, before, after); err != nil {
+		return model.Lot{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Lot{}, model.ErrInternal
+	}
+	return after, nil
+}
+
+func writeLotAudit(ctx context.Context, tx *sqlx.Tx, action string, before *model.Lot, after model.Lot) error {
+	e := auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   after.TenderID,
+		Action:     action,
+		After:      after,
+	}
+	if before != nil {
+		e.Before = before
+	}
+	return writeAudit(ctx, tx, e)
+}
+
+func (r *Repository) GetTenderLots(ctx context.Context, tenderID string) ([]model.Lot, error) {
+	q := `SELECT l.id, l.tender_id, lv.name, lv.description, lv.service_type, l.status, l.winner_bid_id, lv.version, l.created_at
+			FROM lot l
+				INNER JOIN lot_version lv
+					ON l.id = lv.lot_id
+						AND lv.version = (
+							SELECT MAX(version)
+							FROM lot_version
+							WHERE lot_id = l.id
+						)
+			WHERE l.tender_id = $1
+			ORDER BY l.created_at, l.id;`
+
+	lots := make([]model.Lot, 0)
+	if err := r.db.SelectContext(ctx, &lots, q, tenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return lots, nil
+}
+
+type CreateLotInput struct {
+	TenderID    string
+	Name        string
+	Description string
+	ServiceType string
+}
+
+func (r *Repository) CreateLot(ctx context.Context, input CreateLotInput) (model.Lot, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Lot{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// awarding reads the set of open lots under the same lock
+	if _, err = lockTender(ctx, tx, input.TenderID, nil); err != nil {
+		return model.Lot{}, err
+	}
+
+	q := `INSERT INTO lot (tender_id)
+			VALUES ($1)
+			RETURNING id;`
+
+	var lotID string
+	if err = tx.GetContext(ctx, &lotID, q, input.TenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Lot{}, model.ErrInternal
+	}
+
+	q = `INSERT INTO lot_version (lot_id, name, description, service_type)
+			VALUES ($1, $2, $3, $4);`
+
+	if _, err = tx.ExecContext(ctx, q, lotID, input.Name, input.Description, input.ServiceType); err != nil {
+		if isInvalidInput(err) {
+			return model.Lot{}, model.ErrInvalidAttributeValue
+		}
+		logger.Error(ctx, err.Error())
+		return model.Lot{}, model.ErrInternal
+	}
+	lot, err := finishLot(ctx, tx, _auditActionCreateLot, nil, lotID)
+	return lot, err
+}
+
+type EditLotInput struct {
+	LotID       string
+	Name        model.Optional[string]
+	Description model.Optional[string]
+	ServiceType model.Optional[string]
+}
+
+// UpdateLot adds a lot version; omitted fields keep their current values.
+func (r *Repository) UpdateLot(ctx context.Context, input EditLotInput) (model.Lot, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Lot{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockLot(ctx, tx, input.LotID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	c := newVersionCopy(_lotVersions)
+	setOptional(c, "name", input.Name)
+	setOptional(c, "description", input.Description)
+	setOptional(c, "service_type", input.ServiceType)
+	if err = c.exec(ctx, tx, input.LotID); err != nil {
+		return model.Lot{}, err
+	}
+	lot, err := finishLot(ctx, tx, _auditActionEditLot, &before, input.LotID)
+	return lot, err
+}
+
+type RollbackLotInput struct {
+	LotID   string
+	Version int
+}
+
+func (r *Repository) RollbackLot(ctx context.Context, input RollbackLotInput) (model.Lot, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Lot{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockLot(ctx, tx, input.LotID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+
+	q := `INSERT INTO lot_version (lot_id, name, description, service_type, version)
+			SELECT lot_id, name, description, service_type, $3::int + 1
+			FROM lot_version
+			WHERE lot_id = $1 AND version = $2;`
+
+	res, err := tx.ExecContext(ctx, q, input.LotID, input.Version, before.Version)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return model.Lot{}, model.ErrVersionMismatch
+		}
+		logger.Error(ctx, err.Error())
+		return model.Lot{}, model.ErrInternal
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		err = model.ErrNoSuchVersion
+		return model.Lot{}, err
+	}
+	lot, err := finishLot(ctx, tx, _auditActionRollbackLot, &before, input.LotID)
+	return lot, err
+}
+
+func (r *Repository) GetLotVersions(ctx context.Context, lotID string) ([]model.LotVersion, error) {
+	q := `SELECT version, name, description, service_type, created_at
+			FROM lot_version
+			WHERE lot_id = $1
+			ORDER BY version;`
+
+	versions := make([]model.LotVersion, 0)
+	if err := r.db.SelectContext(ctx, &versions, q, lotID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return versions, nil
+}
+
+// awardLot marks the lot won by the bid, cancels the other open bids of the
+// lot and returns how many lots of the tender are still open. The caller holds
+// the tender lock.
+func awardLot(ctx context.Context, tx *sqlx.Tx, lotID, bidID string) (int, error) {
+	before, err := lockLot(ctx, tx, lotID)
+	if err != nil {
+		return 0, err
+	}
+	if before.Status != model.LotStatusOpen {
+		return 0, model.ErrIllegalTransition.Wrap("lot_awarded")
+	}
+
+	q := `UPDATE lot
+			SET status = 'Awarded', winner_bid_id = $2
+			WHERE id = $1;`
+
+	if _, err = tx.ExecContext(ctx, q, lotID, bidID); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+	if err = cancelOpenBids(ctx, tx, before.TenderID, lotID, bidID); err != nil {
+		return 0, err
+	}
+	after, err := getLot(ctx, tx, lotID)
+	if err != nil {
+		return 0, err
+	}
+	if err = writeLotAudit(ctx, tx, _auditActionAwardLot, &before, after); err != nil {
+		return 0, err
+	}
+
+	q = `SELECT COUNT(*) FROM lot WHERE tender_id = $1 AND status = 'Open';`
+
+	var open int
+	if err = tx.GetContext(ctx, &open, q, before.TenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+	return open, nil
+}
+
+// lockLot locks the lot row until the end of the transaction and returns the
+// lot as it was before the change.
+func lockLot(ctx context.Context, tx *sqlx.Tx, lotID string) (model.Lot, error) {
+	q := `SELECT id FROM lot WHERE id = $1 FOR UPDATE;`
+
+	var lockedID string
+	if err := tx.GetContext(ctx, &lockedID, q, lotID); err != nil {
+		return model.Lot{}, model.ErrLotNotFound
+	}
+	return getLot(ctx, tx, lotID)
+}
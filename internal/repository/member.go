@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+func (r *Repository) GetMemberRole(ctx context.Context, orgID, userID string) (string, error) {
+	q := `SELECT role
+		FROM organization_responsible
+		WHERE organization_id = $1 AND user_id = $2;`
+
+	var role string
+	if err := r.db.GetContext(ctx, &role, q, orgID, userID); err != nil {
+		return "", model.ErrMemberNotFound
+	}
+	return role, nil
+}
+
+func (r *Repository) GetOrganizationMembers(ctx context.Context, orgID string) ([]model.OrganizationMember, error) {
+	q := `SELECT r.user_id, e.username, r.role
+		FROM organization_responsible r
+			INNER JOIN employee e ON r.user_id = e.id
+		WHERE r.organization_id = $1
+		ORDER BY e.username;`
+
+	members := make([]model.OrganizationMember, 0)
+	if err := r.db.SelectContext(ctx, &members, q, orgID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return members, nil
+}
+
+type SetMemberRoleInput struct {
+	OrganizationID string
+	UserID         string
+	Role           string
+}
+
+// SetMemberRole adds the employee to the organization or changes the role of an existing member.
+func (r *Repository) SetMemberRole(ctx context.Context, input SetMemberRoleInput) (model.OrganizationMember, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.OrganizationMember{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockMember(ctx, tx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return model.OrganizationMember{}, err
+	}
+
+	q := `WITH upserted AS (
+			INSERT INTO organization_responsible (organization_id, user_id, role)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (organization_id, user_id)
+				DO UPDATE SET role = EXCLUDED.role
+			RETURNING user_id, role
+		)
+		SELECT u.user_id, e.username, u.role
+		FROM upserted u
+			INNER JOIN employee e ON u.user_id = e.id;`
+
+	var member model.OrganizationMember
+	if err = tx.GetContext(ctx, &member, q, input.OrganizationID, input.UserID, input.Role); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.OrganizationMember{}, model.ErrInternal
+	}
+
+	e := auditEvent{
+		EntityType: AuditEntityOrganization,
+		EntityID:   input.OrganizationID,
+		Action:     _auditActionSetMember,
+		After:      member,
+	}
+	if before != nil {
+		e.Before = before
+	}
+	if err = writeAudit(ctx, tx, e); err != nil {
+		return model.OrganizationMember{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.OrganizationMember{}, model.ErrInternal
+	}
+	return member, nil
+}
+
+type RemoveMemberInput struct {
+	OrganizationID string
+	UserID         string
+}
+
+func (r *Repository) RemoveMember(ctx context.Context, input RemoveMemberInput) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockMember(ctx, tx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return err
+	}
+	if before == nil {
+		err = model.ErrMemberNotFound
+		return err
+	}
+
+	q := `DELETE FROM organization_responsible
+		WHERE organization_id = $1 AND user_id = $2;`
+
+	if _, err = tx.ExecContext(ctx, q, input.OrganizationID, input.UserID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityOrganization,
+		EntityID:   input.OrganizationID,
+		Action:     _auditActionRemoveMember,
+		Before:     before,
+	}); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
+
+// lockMember locks the membership row until the end of the transaction and
+// returns it, or nil when the employee is not a member of the organization.
+func lockMember(ctx context.Context, tx *sqlx.Tx, orgID, userID string) (*model.OrganizationMember, error) {
+	q := `SELECT r.user_id, e.username, r.role
+		FROM organization_responsible r
+			INNER JOIN employee e ON r.user_id = e.id
+		WHERE r.organization_id = $1 AND r.user_id = $2
+		FOR UPDATE OF r;`
+
+	var member model.OrganizationMember
+	if err := tx.GetContext(ctx, &member, q, orgID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return &member, nil
+}
+
+type CountMembersWithRoleInput struct {
+	OrganizationID string
+	Role           string
+}
+
+func (r *Repository) CountMembersWithRole(ctx context.Context, input CountMembersWithRoleInput) (int, error) {
+	q := `SELECT COUNT(*)
+		FROM organization_responsible
+		WHERE organization_id = $1 AND role = $2;`
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, q, input.OrganizationID, input.Role); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+	return count, nil
+}
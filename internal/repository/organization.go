@@ -17,3 +17,26 @@ func (r *Repository) GetOrganizationIDByEmployeeID(ctx context.Context, employee
 	}
 	return orgID, nil
 }
+
+func (r *Repository) GetTenderOrganizationID(ctx context.Context, tenderID string) (string, error) {
+	q := `SELECT organization_id FROM tender WHERE id = $1;`
+
+	var orgID string
+	if err := r.db.GetContext(ctx, &orgID, q, tenderID); err != nil {
+		return "", model.ErrTenderNotFound
+	}
+	return orgID, nil
+}
+
+func (r *Repository) GetBidTenderOrganizationID(ctx context.Context, bidID string) (string, error) {
+	q := `SELECT t.organization_id
+		FROM bid b
+			INNER JOIN tender t ON b.tender_id = t.id
+		WHERE b.id = $1;`
+
+	var orgID string
+	if err := r.db.GetContext(ctx, &orgID, q, bidID); err != nil {
+		return "", model.ErrNoBidFound
+	}
+	return orgID, nil
+}
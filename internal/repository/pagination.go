@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+// Pagination selects a page of a list either by Offset or, with Keyset, as
+// the page following Cursor, which is nil for the first page. Keyset pages
+// are ordered by name and ID, so they neither repeat nor skip rows when the
+// list changes between requests.
+type Pagination struct {
+	Limit  int
+	Offset int
+	Keyset bool
+	Cursor *model.Cursor
+	// Total asks to count the whole list.
+	Total bool
+}
+
+// listQuery is a list query to be paged: query selects the rows and ends
+// with its WHERE clause, order is used for offset pages, name and id are
+// the columns keyset pages are ordered by.
+type listQuery struct {
+	query string
+	args  []any
+	order string
+	name  string
+	id    string
+}
+
+// selectPage pages the list query. Keyset pages fetch one row more than
+// the limit to tell whether the next page exists.
+func selectPage[T any](ctx context.Context, db sqlx.QueryerContext, lq listQuery, p Pagination,
+	cursor func(T) model.Cursor) (model.Page[T], error) {
+	var page model.Page[T]
+	if p.Total {
+		var total int
+		q := fmt.Sprintf(`SELECT COUNT(*) FROM (%s) AS list;`, lq.query)
+		if err := sqlx.GetContext(ctx, db, &total, q, lq.args...); err != nil {
+			logger.Error(ctx, err.Error())
+			return model.Page[T]{}, model.ErrInternal
+		}
+		page.Total = &total
+	}
+
+	q, args := lq.query, lq.args
+	if p.Keyset {
+		if p.Cursor != nil {
+			args = append(args[:len(args):len(args)], p.Cursor.Name, p.Cursor.ID)
+			q += fmt.Sprintf(" AND (%s, %s) > ($%d, $%d::uuid)", lq.name, lq.id, len(args)-1, len(args))
+		}
+		args = append(args[:len(args):len(args)], p.Limit+1)
+		q += fmt.Sprintf(" ORDER BY %s, %s LIMIT $%d;", lq.name, lq.id, len(args))
+	} else {
+		args = append(args[:len(args):len(args)], p.Limit, p.Offset)
+		q += fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d;", lq.order, len(args)-1, len(args))
+	}
+
+	page.Items = make([]T, 0)
+	if err := sqlx.SelectContext(ctx, db, &page.Items, q, args...); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Page[T]{}, model.ErrInternal
+	}
+	if p.Keyset && p.Limit > 0 && len(page.Items) > p.Limit {
+		page.Items = page.Items[:p.Limit]
+		next := cursor(page.Items[p.Limit-1])
+		page.Next = &next
+	}
+	return page, nil
+}
+
+func tenderCursor(t model.Tender) model.Cursor {
+	return model.Cursor{Name: t.Name, ID: t.ID}
+}
+
+func bidCursor(b model.Bid) model.Cursor {
+	return model.Cursor{Name: b.Name, ID: b.ID}
+}
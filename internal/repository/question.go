@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+// questionRow keeps the fields of a question that are audited but not shown to bidders.
+type questionRow struct {
+	model.Question
+	TenderID   string  `db:"tender_id" json:"tenderId"`
+	AuthorID   string  `db:"author_id" json:"authorId"`
+	AnsweredBy *string `db:"answered_by" json:"answeredBy,omitempty"`
+}
+
+func getQuestion(ctx context.Context, db sqlx.QueryerContext, questionID, viewerID string) (questionRow, error) {
+	q := `SELECT id, tender_id, author_id, question, answer, answered_by, public,
+				COALESCE(author_id = NULLIF($2, '')::uuid, false) AS mine, created_at, answered_at
+			FROM tender_question
+			WHERE id = $1;`
+
+	var question questionRow
+	if err := sqlx.GetContext(ctx, db, &question, q, questionID, viewerID); err != nil {
+		return questionRow{}, model.ErrQuestionNotFound
+	}
+	return question, nil
+}
+
+type CreateQuestionInput struct {
+	TenderID string
+	AuthorID string
+	Question string
+}
+
+func (r *Repository) CreateQuestion(ctx context.Context, input CreateQuestionInput) (model.Question, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Question{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `INSERT INTO tender_question (tender_id, author_id, question)
+			VALUES ($1, $2, $3)
+			RETURNING id;`
+
+	var questionID string
+	if err = tx.GetContext(ctx, &questionID, q, input.TenderID, input.AuthorID, input.Question); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Question{}, model.ErrInternal
+	}
+	after, err := getQuestion(ctx, tx, questionID, input.AuthorID)
+	if err != nil {
+		return model.Question{}, err
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   input.TenderID,
+		Action:     _auditActionAsk,
+		After:      after,
+	}); err != nil {
+		return model.Question{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Question{}, model.ErrInternal
+	}
+	return after.Question, nil
+}
+
+type GetTenderQuestionsInput struct {
+	TenderID string
+	ViewerID string
+	// All includes unanswered and private questions of other users.
+	All    bool
+	Limit  int
+	Offset int
+}
+
+func (r *Repository) GetTenderQuestions(ctx context.Context, input GetTenderQuestionsInput) ([]model.Question, error) {
+	q := `SELECT id, question, answer, public, COALESCE(author_id = NULLIF($2, '')::uuid, false) AS mine, created_at, answered_at
+			FROM tender_question
+			WHERE tender_id = $1
+				AND ($3 OR public OR author_id = NULLIF($2, '')::uuid)
+			ORDER BY created_at, id
+			LIMIT $4
+			OFFSET $5;`
+
+	questions := make([]model.Question, 0)
+	err := r.db.SelectContext(ctx, &questions, q, input.TenderID, input.ViewerID, input.All, input.Limit, input.Offset)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return questions, nil
+}
+
+type AnswerQuestionInput struct {
+	TenderID   string
+	QuestionID string
+	AnswererID string
+	Answer     string
+	Public     bool
+}
+
+// AnswerQuestion sets or replaces the answer to a question of the tender.
+func (r *Repository) AnswerQuestion(ctx context.Context, input AnswerQuestionInput) (model.Question, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Question{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `SELECT id FROM tender_question WHERE id = $1 AND tender_id = $2 FOR UPDATE;`
+
+	var lockedID string
+	if err = tx.GetContext(ctx, &lockedID, q, input.QuestionID, input.TenderID); err != nil {
+		err = model.ErrQuestionNotFound
+		return model.Question{}, err
+	}
+	before, err := getQuestion(ctx, tx, input.QuestionID, input.AnswererID)
+	if err != nil {
+		return model.Question{}, err
+	}
+
+	q = `UPDATE tender_question
+			SET answer = $2, answered_by = $3, answered_at = CURRENT_TIMESTAMP, public = $4
+			WHERE id = $1;`
+
+	if _, err = tx.ExecContext(ctx, q, input.QuestionID, input.Answer, input.AnswererID, input.Public); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Question{}, model.ErrInternal
+	}
+	after, err := getQuestion(ctx, tx, input.QuestionID, input.AnswererID)
+	if err != nil {
+		return model.Question{}, err
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   input.TenderID,
+		Action:     _auditActionAnswer,
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return model.Question{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Question{}, model.ErrInternal
+	}
+	return after.Question, nil
+}
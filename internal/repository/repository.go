@@ -3,8 +3,21 @@ package repository
 import (
 	"context"
 
-	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+const (
+	_uniqueViolationCode = "23505"
+	_checkViolationCode  = "23514"
+	_foreignKeyCode      = "23503"
+	_notNullCode         = "23502"
+	_invalidTextCode     = "22P02"
+	_tooLongCode         = "22001"
+	_nulCharacterCode    = "22021"
 )
 
 type Repository struct {
@@ -22,46 +35,178 @@ type IRepository interface {
 	IBidRepository
 	IOrganizationRepository
 	IUserRepository
+	IDecisionRepository
+	IReviewRepository
+	IAPIKeyRepository
+	IMemberRepository
+	IAuditRepository
+	ICriterionRepository
+	ILotRepository
+	IAttachmentRepository
+	IQuestionRepository
+	IInvitationRepository
+	IAuctionRepository
 }
 
 type ITenderRepository interface {
 	GetTenderByID(ctx context.Context, tenderID string) (model.Tender, error)
-	GetTenders(ctx context.Context, input GetTendersInput) ([]model.Tender, error)
+	GetTenders(ctx context.Context, input GetTendersInput) (model.Page[model.Tender], error)
 	CreateTender(ctx context.Context, input CreateTenderInput) (model.Tender, error)
 	UpdateTender(ctx context.Context, input EditTenderInput) (model.Tender, error)
 	TenderHasVersion(ctx context.Context, input TenderHasVersionInput) (bool, error)
 	TenderExists(ctx context.Context, tenderID string) bool
 	RollbackTender(ctx context.Context, input RollbackTenderInput) (model.Tender, error)
-	GetMyTenders(ctx context.Context, input GetMyTendersInput) ([]model.Tender, error)
+	GetMyTenders(ctx context.Context, input GetMyTendersInput) (model.Page[model.Tender], error)
 	GetTenderStatus(ctx context.Context, tenderID string) (string, error)
-	CloseTenderByBidID(ctx context.Context, bidID string) error
 	UpdateTenderStatus(ctx context.Context, input UpdateTenderStatusInput) (model.Tender, error)
+	CloseExpiredTenders(ctx context.Context) (int, error)
 	IsTenderExist(ctx context.Context, tenderID string) bool
+	GetTenderVersions(ctx context.Context, tenderID string) ([]model.TenderVersion, error)
+	GetTenderVersion(ctx context.Context, input GetTenderVersionInput) (model.TenderVersion, error)
 }
 
 type IBidRepository interface {
 	GetBidByID(ctx context.Context, bidID string) (model.Bid, error)
 	CreateBid(ctx context.Context, input CreateBidInput) (model.Bid, error)
-	GetMyBids(ctx context.Context, input GetMyBidsInput) ([]model.Bid, error)
+	GetMyBids(ctx context.Context, input GetMyBidsInput) (model.Page[model.Bid], error)
 	BidExists(ctx context.Context, bidID string) bool
-	GetTenderBids(ctx context.Context, input GetTenderBidsInput) ([]model.Bid, error)
+	GetTenderBids(ctx context.Context, input GetTenderBidsInput) (model.Page[model.Bid], error)
 	GetBidStatus(ctx context.Context, bidID string) (string, error)
+	GetBidTenderID(ctx context.Context, bidID string) (string, error)
+	CountTenderBids(ctx context.Context, input CountTenderBidsInput) (int, error)
 	UpdateBidStatus(ctx context.Context, input UpdateBidStatusInput) (model.Bid, error)
 	UpdateBid(ctx context.Context, input EditBidInput) (model.Bid, error)
 	BidHasVersion(ctx context.Context, input BidHasVersionInput) (bool, error)
 	RollbackBid(ctx context.Context, input RollbackBidInput) (model.Bid, error)
+	GetBidVersions(ctx context.Context, bidID string) ([]model.BidVersion, error)
+	GetBidVersion(ctx context.Context, input GetBidVersionInput) (model.BidVersion, error)
+}
+
+type IDecisionRepository interface {
+	DecideBid(ctx context.Context, input DecideBidInput) (model.BidDecisionResult, error)
+}
+
+type IReviewRepository interface {
+	CreateBidReview(ctx context.Context, input CreateBidReviewInput) error
+	GetAuthorReviews(ctx context.Context, input GetAuthorReviewsInput) ([]model.BidReview, error)
+	HasUserBidOnTender(ctx context.Context, tenderID, userID string) bool
+}
+
+type ILotRepository interface {
+	GetLotByID(ctx context.Context, lotID string) (model.Lot, error)
+	GetTenderLots(ctx context.Context, tenderID string) ([]model.Lot, error)
+	CreateLot(ctx context.Context, input CreateLotInput) (model.Lot, error)
+	UpdateLot(ctx context.Context, input EditLotInput) (model.Lot, error)
+	RollbackLot(ctx context.Context, input RollbackLotInput) (model.Lot, error)
+	GetLotVersions(ctx context.Context, lotID string) ([]model.LotVersion, error)
+}
+
+type IAttachmentRepository interface {
+	AddTenderAttachment(ctx context.Context, input AddAttachmentInput) (model.Tender, error)
+	RemoveTenderAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Tender, error)
+	GetTenderAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error)
+	GetTenderAttachment(ctx context.Context, tenderID, attachmentID string) (model.Attachment, error)
+	AddBidAttachment(ctx context.Context, input AddAttachmentInput) (model.Bid, error)
+	RemoveBidAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Bid, error)
+	GetBidAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error)
+	GetBidAttachment(ctx context.Context, bidID, attachmentID string) (model.Attachment, error)
+}
+
+type IQuestionRepository interface {
+	CreateQuestion(ctx context.Context, input CreateQuestionInput) (model.Question, error)
+	GetTenderQuestions(ctx context.Context, input GetTenderQuestionsInput) ([]model.Question, error)
+	AnswerQuestion(ctx context.Context, input AnswerQuestionInput) (model.Question, error)
+}
+
+type ICriterionRepository interface {
+	GetTenderCriteria(ctx context.Context, tenderID string) ([]model.Criterion, error)
+	SetTenderCriteria(ctx context.Context, input SetTenderCriteriaInput) ([]model.Criterion, error)
+	SetBidScores(ctx context.Context, input SetBidScoresInput) ([]model.BidScore, error)
+	GetTenderScores(ctx context.Context, tenderID string) ([]model.CriterionScore, error)
+}
+
+type IAPIKeyRepository interface {
+	CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (model.APIKey, error)
+	GetOrganizationAPIKeys(ctx context.Context, orgID string) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, input RevokeAPIKeyInput) (model.APIKey, error)
+	UseAPIKey(ctx context.Context, keyHash string) (APIKeyOwner, error)
+}
+
+type IAuditRepository interface {
+	GetAuditEvents(ctx context.Context, input GetAuditEventsInput) ([]model.AuditEvent, error)
+}
+
+type IMemberRepository interface {
+	GetMemberRole(ctx context.Context, orgID, userID string) (string, error)
+	GetOrganizationMembers(ctx context.Context, orgID string) ([]model.OrganizationMember, error)
+	SetMemberRole(ctx context.Context, input SetMemberRoleInput) (model.OrganizationMember, error)
+	RemoveMember(ctx context.Context, input RemoveMemberInput) error
+	CountMembersWithRole(ctx context.Context, input CountMembersWithRoleInput) (int, error)
 }
 
 type IOrganizationRepository interface {
 	GetOrganizationIDByEmployeeID(ctx context.Context, employeeID string) (string, error)
+	GetTenderOrganizationID(ctx context.Context, tenderID string) (string, error)
+	GetBidTenderOrganizationID(ctx context.Context, bidID string) (string, error)
+}
+
+type IInvitationRepository interface {
+	TenderAdmits(ctx context.Context, input TenderAdmitsInput) (bool, error)
+	GetTenderInvitations(ctx context.Context, tenderID string) ([]model.Invitation, error)
+	CreateInvitation(ctx context.Context, input CreateInvitationInput) (model.Invitation, error)
+	RemoveInvitation(ctx context.Context, input RemoveInvitationInput) error
+}
+
+type IAuctionRepository interface {
+	GetAuction(ctx context.Context, tender model.Tender, lotID *string) (model.Auction, error)
 }
 
 type IUserRepository interface {
-	IsUserOrganizationResponsible(ctx context.Context, userID, orgID string) bool
 	GetUserIDByUsername(ctx context.Context, username string) (string, error)
-	IsUserResponsibleForTender(ctx context.Context, tenderID, userID string) bool
-	IsBidVisibleForUser(ctx context.Context, userID, bidID string) (bool, error)
-	UserCanSubmitDecision(ctx context.Context, bidID, userID string) bool
 	GetUserIDByBidID(ctx context.Context, bidID string) (string, error)
 	IsUserExist(ctx context.Context, userID string) bool
+	GetEmployeeCredentials(ctx context.Context, username string) (EmployeeCredentials, error)
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == _uniqueViolationCode
+}
+
+func isCheckViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == _checkViolationCode
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == _foreignKeyCode
+}
+
+func isNotNullViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == _notNullCode
+}
+
+// isInvalidInput reports whether the value does not fit the column type,
+// e.g. an unknown enum label, a too long string or text with NUL.
+func isInvalidInput(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	switch pgErr.Code {
+	case _invalidTextCode, _tooLongCode, _nulCharacterCode:
+		return true
+	}
+	return false
+}
+
+// numericArg passes the amount as text, which Postgres parses into NUMERIC exactly.
+func numericArg(a *model.Amount) *string {
+	if a == nil {
+		return nil
+	}
+	s := string(*a)
+	return &s
 }
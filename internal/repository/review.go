@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+type CreateBidReviewInput struct {
+	BidID       string
+	AuthorID    string
+	Description string
+}
+
+func (r *Repository) CreateBidReview(ctx context.Context, input CreateBidReviewInput) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	q := `INSERT INTO bid_review (bid_id, author_id, description)
+			VALUES ($1, $2, $3)
+			RETURNING id, description, created_at;`
+
+	var review model.BidReview
+	if err = tx.GetContext(ctx, &review, q, input.BidID, input.AuthorID, input.Description); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	if err = writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityBid,
+		EntityID:   input.BidID,
+		Action:     _auditActionFeedback,
+		After:      review,
+	}); err != nil {
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
+
+type GetAuthorReviewsInput struct {
+	AuthorID string
+	Limit    int
+	Offset   int
+}
+
+// GetAuthorReviews returns reviews left on any bid created by the author.
+func (r *Repository) GetAuthorReviews(ctx context.Context, input GetAuthorReviewsInput) ([]model.BidReview, error) {
+	q := `SELECT br.id, br.description, br.created_at
+			FROM bid_review br
+				INNER JOIN bid b ON br.bid_id = b.id
+			WHERE b.author_id = $1
+			ORDER BY br.created_at DESC
+			LIMIT $2
+			OFFSET $3;`
+
+	reviews := make([]model.BidReview, 0)
+	if err := r.db.SelectContext(ctx, &reviews, q, input.AuthorID, input.Limit, input.Offset); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return reviews, nil
+}
+
+func (r *Repository) HasUserBidOnTender(ctx context.Context, tenderID, userID string) bool {
+	q := `SELECT EXISTS (
+			SELECT id
+			FROM bid
+			WHERE tender_id = $1 AND author_id = $2
+		);`
+
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, q, tenderID, userID); err != nil {
+		logger.Error(ctx, err.Error())
+		return false
+	}
+	return exists
+}
@@ -3,86 +3,135 @@ package repository
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
 
 	"github.com/b0pof/avito-internship/internal/model"
 	"github.com/b0pof/avito-internship/pkg/logger"
 )
 
 func (r *Repository) GetTenderByID(ctx context.Context, tenderID string) (model.Tender, error) {
-	q := `SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.version, t.created_at
+	return getTender(ctx, r.db, tenderID)
+}
+
+func getTender(ctx context.Context, db sqlx.QueryerContext, tenderID string) (model.Tender, error) {
+	q := `SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.submission_deadline, tv.budget, tv.budget_currency, tv.enforce_budget, t.sealed, t.private,
+			t.auction, t.auction_start, t.auction_step, t.auction_extension, tv.version, t.created_at
 		FROM tender_version tv
 			INNER JOIN tender t on tv.tender_id = t.id
 		WHERE tender_id = $1
 			AND version = (SELECT MAX(version) FROM tender_version WHERE tender_id = $1);`
 
 	var tender model.Tender
-	if err := r.db.GetContext(ctx, &tender, q, tenderID); err != nil {
+	if err := sqlx.GetContext(ctx, db, &tender, q, tenderID); err != nil {
 		return model.Tender{}, model.ErrTenderNotFound
 	}
 	return tender, nil
 }
 
-type GetTendersInput struct {
-	ServiceTypes []string
-	Limit        int
-	Offset       int
+// finishTender records the change of the tender made in tx and commits it.
+func finishTender(ctx context.Context, tx *sqlx.Tx, action string, before *model.Tender, tenderID string) (model.Tender, error) {
+	after, err := getTender(ctx, tx, tenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	e := auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   tenderID,
+		Action:     action,
+		After:      after,
+	}
+	if before != nil {
+		e.Before = before
+	}
+	if err = writeAudit(ctx, tx, e); err != nil {
+		return model.Tender{}, err
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.Tender{}, model.ErrInternal
+	}
+	return after, nil
 }
 
-func (r *Repository) GetTenders(ctx context.Context, input GetTendersInput) ([]model.Tender, error) {
-	var q string
-	tenders := make([]model.Tender, 0)
+type GetTendersInput struct {
+	ServiceTypes   []string
+	BudgetMin      *model.Amount
+	BudgetMax      *model.Amount
+	BudgetCurrency string
+	// ViewerID and ViewerOrganizationID identify the caller, for whom private
+	// tenders are listed if invited; both are empty for anonymous requests.
+	ViewerID             string
+	ViewerOrganizationID string
+	Pagination
+}
 
-	if len(input.ServiceTypes) == 0 {
-		q = `SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.version, t.created_at
-				FROM tender t
-				INNER JOIN tender_version tv
-					ON t.id = tv.tender_id
-					AND tv.version = (
-						SELECT MAX(version)
-						FROM tender_version
-						WHERE tender_id = t.id
-					)
-				WHERE status = 'Published'
-				ORDER BY tv.name
-				LIMIT $1
-				OFFSET $2;`
-		if err := r.db.SelectContext(ctx, &tenders, q, input.Limit, input.Offset); err != nil {
-			logger.Error(ctx, err.Error())
-			return nil, model.ErrInternal
-		}
-	} else {
-		q = `SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.version, t.created_at
-				FROM tender t
-				INNER JOIN tender_version tv
-					ON t.id = tv.tender_id
-					AND tv.version = (
-						SELECT MAX(version)
-						FROM tender_version
-						WHERE tender_id = t.id
-					)
-				WHERE service_type = ANY($1::tender_service_type[]) AND status = 'Published'
-				ORDER BY tv.name
-				LIMIT $2
-				OFFSET $3;`
-		if err := r.db.SelectContext(ctx, &tenders, q, input.ServiceTypes, input.Limit, input.Offset); err != nil {
-			logger.Error(ctx, err.Error())
-			return nil, model.ErrInternal
-		}
+func (r *Repository) GetTenders(ctx context.Context, input GetTendersInput) (model.Page[model.Tender], error) {
+	conditions := []string{"t.status = 'Published'"}
+	args := make([]any, 0, 6)
+	where := func(cond string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(cond, len(args)))
+	}
+	if len(input.ServiceTypes) != 0 {
+		where("tv.service_type = ANY($%d::tender_service_type[])", input.ServiceTypes)
+	}
+	if input.BudgetMin != nil {
+		where("tv.budget >= $%d::numeric", numericArg(input.BudgetMin))
 	}
-	return tenders, nil
+	if input.BudgetMax != nil {
+		where("tv.budget <= $%d::numeric", numericArg(input.BudgetMax))
+	}
+	if input.BudgetCurrency != "" {
+		where("tv.budget_currency = $%d", input.BudgetCurrency)
+	}
+	args = append(args, input.ViewerID, input.ViewerOrganizationID)
+	conditions = append(conditions, fmt.Sprintf("(NOT t.private OR %s)", tenderAdmits("t", len(args)-1, len(args))))
+
+	q := fmt.Sprintf(`SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.submission_deadline, tv.budget, tv.budget_currency, tv.enforce_budget, t.sealed, t.private,
+			t.auction, t.auction_start, t.auction_step, t.auction_extension, tv.version, t.created_at
+			FROM tender t
+			INNER JOIN tender_version tv
+				ON t.id = tv.tender_id
+				AND tv.version = (
+					SELECT MAX(version)
+					FROM tender_version
+					WHERE tender_id = t.id
+				)
+			WHERE %s`, strings.Join(conditions, " AND "))
+
+	return selectPage(ctx, r.db, listQuery{
+		query: q,
+		args:  args,
+		order: "tv.name, t.id",
+		name:  "tv.name",
+		id:    "t.id",
+	}, input.Pagination, tenderCursor)
 }
 
 type CreateTenderInput struct {
-	Name           string
-	Description    string
-	ServiceType    string
-	OrganizationID string
-	CreatorID      string
+	Name               string
+	Description        string
+	ServiceType        string
+	SubmissionDeadline *time.Time
+	Budget             *model.Amount
+	BudgetCurrency     *string
+	EnforceBudget      bool
+	Sealed             bool
+	Private            bool
+	Auction            bool
+	AuctionStart       *time.Time
+	AuctionStep        *model.Amount
+	AuctionExtension   int
+	OrganizationID     string
+	CreatorID          string
 }
 
 func (r *Repository) CreateTender(ctx context.Context, input CreateTenderInput) (model.Tender, error) {
-	tx, err := r.db.Beginx()
+	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		return model.Tender{}, model.ErrInternal
 	}
@@ -92,72 +141,90 @@ func (r *Repository) CreateTender(ctx context.Context, input CreateTenderInput)
 		}
 	}()
 
-	q := `INSERT INTO tender (organization_id, author_id)
-			VALUES ($1, $2)
+	q := `INSERT INTO tender (organization_id, author_id, sealed, private,
+				auction, auction_start, auction_step, auction_extension)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 			RETURNING id;`
 
 	var tenderID string
-	if err = tx.GetContext(ctx, &tenderID, q, input.OrganizationID, input.CreatorID); err != nil {
+	err = tx.GetContext(ctx, &tenderID, q, input.OrganizationID, input.CreatorID, input.Sealed, input.Private,
+		input.Auction, input.AuctionStart, numericArg(input.AuctionStep), input.AuctionExtension)
+	if err != nil {
 		logger.Error(ctx, err.Error())
 		return model.Tender{}, model.ErrInternal
 	}
 
-	q = `INSERT INTO tender_version (tender_id, name, description, service_type, version)
-			SELECT $1, $2, $3, $4, COALESCE(tv.version, 0) + 1
+	q = `INSERT INTO tender_version (tender_id, name, description, service_type, submission_deadline,
+				budget, budget_currency, enforce_budget, version)
+			SELECT $1, $2, $3, $4, $5, $6, $7, $8, COALESCE(tv.version, 0) + 1
 			FROM tender t
 				LEFT JOIN tender_version tv ON t.id = tv.tender_id
 			WHERE t.id = $1
 			ORDER BY version DESC
 			LIMIT 1;`
 
-	if _, err = tx.ExecContext(ctx, q, tenderID, input.Name, input.Description, input.ServiceType); err != nil {
+	_, err = tx.ExecContext(ctx, q, tenderID, input.Name, input.Description, input.ServiceType, input.SubmissionDeadline,
+		numericArg(input.Budget), input.BudgetCurrency, input.EnforceBudget)
+	if err != nil {
 		logger.Error(ctx, err.Error())
 		return model.Tender{}, model.ErrInternal
 	}
-	_ = tx.Commit()
-	return r.GetTenderByID(ctx, tenderID)
+	tender, err := finishTender(ctx, tx, _auditActionCreate, nil, tenderID)
+	return tender, err
 }
 
 type EditTenderInput struct {
-	TenderID    string
-	Name        string
-	Description string
-	ServiceType string
+	TenderID           string
+	Name               model.Optional[string]
+	Description        model.Optional[string]
+	ServiceType        model.Optional[string]
+	SubmissionDeadline model.Optional[time.Time]
+	// Budget and BudgetCurrency are changed together.
+	Budget           model.Optional[model.Amount]
+	BudgetCurrency   model.Optional[string]
+	EnforceBudget    model.Optional[bool]
+	ExpectedVersions []int
 }
 
-func (r *Repository) UpdateTender(ctx context.Context, input EditTenderInput) (model.Tender, error) {
-	q := `INSERT INTO tender_version (tender_id, name, description, service_type, version)
-			SELECT t.id, %s, %s, %s, COALESCE(tv.version, 0) + 1
-			FROM tender t
-				LEFT JOIN tender_version tv ON t.id = tv.tender_id
-			WHERE t.id = $1
-			ORDER BY version DESC
-			LIMIT 1;`
-
-	format := "'%s'"
-
-	var name = "name"
-	if input.Name != "" {
-		name = fmt.Sprintf(format, input.Name)
+// tenderEdit is the version copy made by the partial update.
+func tenderEdit(input EditTenderInput) *versionCopy {
+	c := newVersionCopy(_tenderVersions)
+	setOptional(c, "name", input.Name)
+	setOptional(c, "description", input.Description)
+	setOptional(c, "service_type", input.ServiceType)
+	setOptional(c, "submission_deadline", input.SubmissionDeadline)
+	if input.Budget.Set {
+		c.set("budget", numericArg(input.Budget.Ptr()))
 	}
-	var description = "description"
-	if input.Description != "" {
-		description = fmt.Sprintf(format, input.Description)
+	setOptional(c, "budget_currency", input.BudgetCurrency)
+	setOptional(c, "enforce_budget", input.EnforceBudget)
+	return c
+}
+
+func (r *Repository) UpdateTender(ctx context.Context, input EditTenderInput) (model.Tender, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Tender{}, model.ErrInternal
 	}
-	var serviceType = "service_type"
-	if input.ServiceType != "" {
-		serviceType = fmt.Sprintf(format, input.ServiceType)
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockTender(ctx, tx, input.TenderID, input.ExpectedVersions)
+	if err != nil {
+		return model.Tender{}, err
 	}
-	q = fmt.Sprintf(q, name, description, serviceType)
 
-	if _, err := r.db.ExecContext(ctx, q, input.TenderID); err != nil {
-		if strings.Contains(err.Error(), "invalid value") {
-			return model.Tender{}, model.ErrInvalidAttributeValue
-		}
-		logger.Error(ctx, err.Error())
-		return model.Tender{}, model.ErrInternal
+	if err = tenderEdit(input).exec(ctx, tx, input.TenderID); err != nil {
+		return model.Tender{}, err
+	}
+	if err = carryAttachments(ctx, tx, _tenderAttachments, input.TenderID); err != nil {
+		return model.Tender{}, err
 	}
-	return r.GetTenderByID(ctx, input.TenderID)
+	tender, err := finishTender(ctx, tx, _auditActionEdit, &before, input.TenderID)
+	return tender, err
 }
 
 type TenderHasVersionInput struct {
@@ -192,34 +259,59 @@ func (r *Repository) TenderExists(ctx context.Context, tenderID string) bool {
 }
 
 type RollbackTenderInput struct {
-	TenderID string
-	Version  int
+	TenderID         string
+	Version          int
+	ExpectedVersions []int
 }
 
 func (r *Repository) RollbackTender(ctx context.Context, input RollbackTenderInput) (model.Tender, error) {
-	q := `INSERT INTO tender_version (tender_id, name, description, service_type, version)
-			SELECT t.id, name, description, service_type, COALESCE(
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Tender{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockTender(ctx, tx, input.TenderID, input.ExpectedVersions)
+	if err != nil {
+		return model.Tender{}, err
+	}
+
+	q := `INSERT INTO tender_version (tender_id, name, description, service_type, submission_deadline,
+				budget, budget_currency, enforce_budget, version)
+			SELECT t.id, name, description, service_type, submission_deadline,
+				budget, budget_currency, enforce_budget, COALESCE(
 				(SELECT MAX(version) FROM tender_version WHERE tender_id = $1), 0
 			) + 1
 			FROM tender t
 				LEFT JOIN tender_version tv ON t.id = tv.tender_id
 			WHERE t.id = $1 AND tv.version = $2;`
 
-	if _, err := r.db.ExecContext(ctx, q, input.TenderID, input.Version); err != nil {
+	if _, err = tx.ExecContext(ctx, q, input.TenderID, input.Version); err != nil {
+		if isUniqueViolation(err) {
+			return model.Tender{}, model.ErrVersionMismatch
+		}
 		logger.Error(ctx, err.Error())
 		return model.Tender{}, model.ErrInternal
 	}
-	return r.GetTenderByID(ctx, input.TenderID)
+	if err = restoreAttachments(ctx, tx, _tenderAttachments, input.TenderID, input.Version); err != nil {
+		return model.Tender{}, err
+	}
+	tender, err := finishTender(ctx, tx, _auditActionRollback, &before, input.TenderID)
+	return tender, err
 }
 
 type GetMyTendersInput struct {
-	Limit  int
-	Offset int
 	UserID string
+	Pagination
 }
 
-func (r *Repository) GetMyTenders(ctx context.Context, input GetMyTendersInput) ([]model.Tender, error) {
-	q := `SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.version, t.created_at
+func (r *Repository) GetMyTenders(ctx context.Context, input GetMyTendersInput) (model.Page[model.Tender], error) {
+	q := `SELECT t.id, tv.name, tv.description, t.status, tv.service_type, tv.submission_deadline, tv.budget, tv.budget_currency, tv.enforce_budget, t.sealed, t.private,
+			t.auction, t.auction_start, t.auction_step, t.auction_extension, tv.version, t.created_at
 			FROM tender t
 			INNER JOIN tender_version tv
 				ON t.id = tv.tender_id
@@ -228,17 +320,15 @@ func (r *Repository) GetMyTenders(ctx context.Context, input GetMyTendersInput)
 					FROM tender_version
 					WHERE tender_id = t.id
 				)
-			WHERE t.author_id = $1
-			ORDER BY tv.name
-			LIMIT $2
-			OFFSET $3;`
-
-	tenders := make([]model.Tender, 0)
-	if err := r.db.SelectContext(ctx, &tenders, q, input.UserID, input.Limit, input.Offset); err != nil {
-		logger.Error(ctx, err.Error())
-		return nil, model.ErrInternal
-	}
-	return tenders, nil
+			WHERE t.author_id = $1`
+
+	return selectPage(ctx, r.db, listQuery{
+		query: q,
+		args:  []any{input.UserID},
+		order: "tv.name, t.id",
+		name:  "tv.name",
+		id:    "t.id",
+	}, input.Pagination, tenderCursor)
 }
 
 func (r *Repository) GetTenderStatus(ctx context.Context, tenderID string) (string, error) {
@@ -251,38 +341,180 @@ func (r *Repository) GetTenderStatus(ctx context.Context, tenderID string) (stri
 	return status, nil
 }
 
-func (r *Repository) CloseTenderByBidID(ctx context.Context, bidID string) error {
-	q := `UPDATE tender
-		SET status = 'Closed'
-		WHERE id = (
-			SELECT tender_id
-			FROM bid
-			WHERE id = $1
-		);`
-
-	if _, err := r.db.ExecContext(ctx, q, bidID); err != nil {
-		return model.ErrTenderNotFound
-	}
-	return nil
-}
-
 type UpdateTenderStatusInput struct {
-	TenderID string
-	Status   string
+	TenderID         string
+	FromStatus       string
+	Status           string
+	ExpectedVersions []int
 }
 
+// UpdateTenderStatus moves the tender from FromStatus to Status. The change is
+// refused with ErrIllegalTransition if the status was changed concurrently.
 func (r *Repository) UpdateTenderStatus(ctx context.Context, input UpdateTenderStatusInput) (model.Tender, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return model.Tender{}, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	before, err := lockTender(ctx, tx, input.TenderID, input.ExpectedVersions)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	if before.Status != input.FromStatus {
+		err = model.ErrIllegalTransition
+		return model.Tender{}, err
+	}
+
 	q := `UPDATE tender
 			SET status = $1
 			WHERE id = $2;`
 
-	if _, err := r.db.ExecContext(ctx, q, input.Status, input.TenderID); err != nil {
+	if _, err = tx.ExecContext(ctx, q, input.Status, input.TenderID); err != nil {
 		if strings.Contains(err.Error(), "invalid input") {
 			return model.Tender{}, model.ErrInvalidAttributeValue
 		}
 		return model.Tender{}, model.ErrTenderNotFound
 	}
-	return r.GetTenderByID(ctx, input.TenderID)
+	if err = bumpVersion(ctx, tx, _tenderVersions, _tenderAttachments, input.TenderID); err != nil {
+		return model.Tender{}, err
+	}
+	if input.Status == model.TenderStatusClosed {
+		if err = cancelOpenBids(ctx, tx, input.TenderID, "", ""); err != nil {
+			return model.Tender{}, err
+		}
+	}
+	tender, err := finishTender(ctx, tx, _auditActionStatus, &before, input.TenderID)
+	return tender, err
+}
+
+// awardBid closes the locked tender, unless it is already closed by the
+// deadline, and cancels every open bid of it except the winner. A bid made for
+// a lot awards only that lot and cancels the other bids of it; the tender is
+// closed once no open lots are left.
+func awardBid(ctx context.Context, tx *sqlx.Tx, before model.Tender, bidID string) error {
+	var lotID *string
+	if err := tx.GetContext(ctx, &lotID, `SELECT lot_id FROM bid WHERE id = $1;`, bidID); err != nil {
+		return model.ErrNoBidFound
+	}
+	if lotID != nil {
+		openLots, err := awardLot(ctx, tx, *lotID, bidID)
+		if err != nil || openLots > 0 {
+			return err
+		}
+	}
+
+	q := `UPDATE tender
+			SET status = 'Closed'
+			WHERE id = $1;`
+
+	if _, err := tx.ExecContext(ctx, q, before.ID); err != nil {
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	if err := bumpVersion(ctx, tx, _tenderVersions, _tenderAttachments, before.ID); err != nil {
+		return err
+	}
+	if err := cancelOpenBids(ctx, tx, before.ID, "", bidID); err != nil {
+		return err
+	}
+	after, err := getTender(ctx, tx, before.ID)
+	if err != nil {
+		return err
+	}
+	return writeAudit(ctx, tx, auditEvent{
+		EntityType: AuditEntityTender,
+		EntityID:   before.ID,
+		Action:     _auditActionAward,
+		Before:     &before,
+		After:      after,
+	})
+}
+
+// _deadlineLockKey is the advisory lock key guarding closing of expired
+// tenders, so that only one replica does it at a time.
+const _deadlineLockKey = 7_245_019_331
+
+// CloseExpiredTenders closes published tenders whose submission deadline has
+// passed. Open bids are kept for evaluation. If another replica holds the
+// lock, nothing is done.
+func (r *Repository) CloseExpiredTenders(ctx context.Context) (int, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, model.ErrInternal
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var locked bool
+	if err = tx.GetContext(ctx, &locked, `SELECT pg_try_advisory_xact_lock($1);`, _deadlineLockKey); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+	if !locked {
+		_ = tx.Rollback()
+		return 0, nil
+	}
+
+	q := `SELECT t.id
+			FROM tender t
+				INNER JOIN tender_version tv
+					ON t.id = tv.tender_id
+					AND tv.version = (
+						SELECT MAX(version)
+						FROM tender_version
+						WHERE tender_id = t.id
+					)
+			WHERE t.status = 'Published' AND tv.submission_deadline <= now()
+			FOR UPDATE OF t SKIP LOCKED;`
+
+	var tenderIDs []string
+	if err = tx.SelectContext(ctx, &tenderIDs, q); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+
+	q = `UPDATE tender
+			SET status = 'Closed'
+			WHERE id = $1;`
+
+	for _, tenderID := range tenderIDs {
+		var before, after model.Tender
+		if before, err = getTender(ctx, tx, tenderID); err != nil {
+			return 0, err
+		}
+		if _, err = tx.ExecContext(ctx, q, tenderID); err != nil {
+			logger.Error(ctx, err.Error())
+			return 0, model.ErrInternal
+		}
+		if err = bumpVersion(ctx, tx, _tenderVersions, _tenderAttachments, tenderID); err != nil {
+			return 0, err
+		}
+		if after, err = getTender(ctx, tx, tenderID); err != nil {
+			return 0, err
+		}
+		if err = writeAudit(ctx, tx, auditEvent{
+			EntityType: AuditEntityTender,
+			EntityID:   tenderID,
+			Action:     _auditActionDeadline,
+			Before:     before,
+			After:      after,
+		}); err != nil {
+			return 0, err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		logger.Error(ctx, err.Error())
+		return 0, model.ErrInternal
+	}
+	return len(tenderIDs), nil
 }
 
 func (r *Repository) IsTenderExist(ctx context.Context, tenderID string) bool {
@@ -295,3 +527,65 @@ func (r *Repository) IsTenderExist(ctx context.Context, tenderID string) bool {
 	}
 	return foundTenderID == tenderID
 }
+
+func (r *Repository) GetTenderVersions(ctx context.Context, tenderID string) ([]model.TenderVersion, error) {
+	q := `SELECT version, name, description, service_type, submission_deadline, budget, budget_currency, enforce_budget, created_at
+			FROM tender_version
+			WHERE tender_id = $1
+			ORDER BY version;`
+
+	versions := make([]model.TenderVersion, 0)
+	if err := r.db.SelectContext(ctx, &versions, q, tenderID); err != nil {
+		logger.Error(ctx, err.Error())
+		return nil, model.ErrInternal
+	}
+	return versions, nil
+}
+
+type GetTenderVersionInput struct {
+	TenderID string
+	Version  int
+}
+
+func (r *Repository) GetTenderVersion(ctx context.Context, input GetTenderVersionInput) (model.TenderVersion, error) {
+	q := `SELECT version, name, description, service_type, submission_deadline, budget, budget_currency, enforce_budget, created_at
+			FROM tender_version
+			WHERE tender_id = $1 AND version = $2;`
+
+	var version model.TenderVersion
+	if err := r.db.GetContext(ctx, &version, q, input.TenderID, input.Version); err != nil {
+		return model.TenderVersion{}, model.ErrNoSuchVersion
+	}
+	return version, nil
+}
+
+// copyTenderVersion adds a version of the tender with the fields of the latest one.
+func copyTenderVersion(ctx context.Context, tx *sqlx.Tx, tenderID string) error {
+	return newVersionCopy(_tenderVersions).exec(ctx, tx, tenderID)
+}
+
+// lockTender locks the tender row until the end of the transaction, so that
+// concurrent edits are serialized, checks that its latest version is one of
+// expectedVersions and returns the tender as it was before the change.
+// No expectedVersions skips the check.
+func lockTender(ctx context.Context, tx *sqlx.Tx, tenderID string, expectedVersions []int) (model.Tender, error) {
+	q := `SELECT id FROM tender WHERE id = $1 FOR UPDATE;`
+
+	var lockedID string
+	if err := tx.GetContext(ctx, &lockedID, q, tenderID); err != nil {
+		return model.Tender{}, model.ErrTenderNotFound
+	}
+	if len(expectedVersions) > 0 {
+		q = `SELECT COALESCE(MAX(version), 0) FROM tender_version WHERE tender_id = $1;`
+
+		var version int
+		if err := tx.GetContext(ctx, &version, q, tenderID); err != nil {
+			logger.Error(ctx, err.Error())
+			return model.Tender{}, model.ErrInternal
+		}
+		if !slices.Contains(expectedVersions, version) {
+			return model.Tender{}, model.ErrVersionMismatch
+		}
+	}
+	return getTender(ctx, tx, tenderID)
+}
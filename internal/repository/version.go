@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+// versionTable describes a table of entity versions. Only these identifiers
+// are put into SQL text; values always go as parameters.
+type versionTable struct {
+	name    string
+	owner   string
+	columns []versionColumn
+}
+
+// versionColumn is a copied column with the type its parameter is cast to.
+type versionColumn struct {
+	name string
+	cast string
+}
+
+var (
+	_tenderVersions = versionTable{
+		name:  "tender_version",
+		owner: "tender_id",
+		columns: []versionColumn{
+			{"name", "text"},
+			{"description", "text"},
+			{"service_type", "tender_service_type"},
+			{"submission_deadline", "timestamptz"},
+			{"budget", "numeric"},
+			{"budget_currency", "text"},
+			{"enforce_budget", "boolean"},
+		},
+	}
+	_bidVersions = versionTable{
+		name:  "bid_version",
+		owner: "bid_id",
+		columns: []versionColumn{
+			{"name", "text"},
+			{"description", "text"},
+			{"price", "numeric"},
+			{"currency", "text"},
+		},
+	}
+	_lotVersions = versionTable{
+		name:  "lot_version",
+		owner: "lot_id",
+		columns: []versionColumn{
+			{"name", "text"},
+			{"description", "text"},
+			{"service_type", "tender_service_type"},
+		},
+	}
+)
+
+// versionCopy adds the next version of an entity, copying the latest one
+// except for the columns given new values.
+type versionCopy struct {
+	table versionTable
+	exprs map[string]string
+	args  []any
+}
+
+func newVersionCopy(table versionTable) *versionCopy {
+	return &versionCopy{
+		table: table,
+		exprs: make(map[string]string, len(table.columns)),
+	}
+}
+
+// set gives the column a new value; nil sets it to NULL.
+func (c *versionCopy) set(column string, value any) *versionCopy {
+	for _, col := range c.table.columns {
+		if col.name == column {
+			c.args = append(c.args, value)
+			// $1 is the owner ID
+			c.exprs[column] = fmt.Sprintf("$%d::%s", len(c.args)+1, col.cast)
+			return c
+		}
+	}
+	panic("unknown column " + column + " of " + c.table.name)
+}
+
+// setOptional sets the column if the field of a partial update is present.
+func setOptional[T any](c *versionCopy, column string, o model.Optional[T]) *versionCopy {
+	if !o.Set {
+		return c
+	}
+	return c.set(column, o.Ptr())
+}
+
+func (c *versionCopy) query() string {
+	columns := make([]string, 0, len(c.table.columns))
+	values := make([]string, 0, len(c.table.columns))
+	for _, col := range c.table.columns {
+		columns = append(columns, col.name)
+		if expr, ok := c.exprs[col.name]; ok {
+			values = append(values, expr)
+		} else {
+			values = append(values, col.name)
+		}
+	}
+	return fmt.Sprintf(`INSERT INTO %[1]s (%[2]s, %[3]s, version)
+			SELECT %[2]s, %[4]s, version + 1
+			FROM %[1]s
+			WHERE %[2]s = $1
+			ORDER BY version DESC
+			LIMIT 1;`, c.table.name, c.table.owner, strings.Join(columns, ", "), strings.Join(values, ", "))
+}
+
+// bumpVersion adds an unchanged copy of the latest version with its
+// attachments. Status changes bump the version, so that the ETag derived from
+// it changes with the status.
+func bumpVersion(ctx context.Context, tx *sqlx.Tx, table versionTable, o attachmentOwner, ownerID string) error {
+	if err := newVersionCopy(table).exec(ctx, tx, ownerID); err != nil {
+		return err
+	}
+	return carryAttachments(ctx, tx, o, ownerID)
+}
+
+func (c *versionCopy) exec(ctx context.Context, tx *sqlx.Tx, ownerID string) error {
+	args := append([]any{ownerID}, c.args...)
+	if _, err := tx.ExecContext(ctx, c.query(), args...); err != nil {
+		switch {
+		case isUniqueViolation(err):
+			return model.ErrVersionMismatch
+		case isInvalidInput(err), isCheckViolation(err), isNotNullViolation(err):
+			return model.ErrInvalidAttributeValue
+		}
+		logger.Error(ctx, err.Error())
+		return model.ErrInternal
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+const _ownerID = "3fa85f64-5717-4562-b3fc-2c963f66afa6"
+
+var _hostileValues = []string{
+	"O'Brien",
+	"O'Brien'; DROP TABLE tender;--",
+	`'); DELETE FROM bid; --`,
+	`$1::text, $2`,
+	`"quoted" \ backslash`,
+	"Тендер «Сибирь» — поставка 🚧",
+	"",
+}
+
+func TestIsInvalidInput(t *testing.T) {
+	// text with NUL is rejected by Postgres with 22021
+	for _, code := range []string{_invalidTextCode, _tooLongCode, _nulCharacterCode} {
+		if !isInvalidInput(&pgconn.PgError{Code: code}) {
+			t.Errorf("isInvalidInput(%s) = false", code)
+		}
+	}
+	if isInvalidInput(&pgconn.PgError{Code: _uniqueViolationCode}) || isInvalidInput(errors.New("other")) {
+		t.Error("isInvalidInput accepts other errors")
+	}
+}
+
+func squash(q string) string {
+	return strings.Join(strings.Fields(q), " ")
+}
+
+func TestVersionCopyQuery(t *testing.T) {
+	amount := model.Amount("100.50")
+	c := tenderEdit(EditTenderInput{
+		Budget:         model.Optional[model.Amount]{Set: true, Value: amount},
+		Name:           model.Optional[string]{Set: true, Value: "name"},
+		BudgetCurrency: model.Optional[string]{Set: true, Null: true},
+	})
+
+	want := "INSERT INTO tender_version (tender_id, name, description, service_type, submission_deadline, budget, budget_currency, enforce_budget, version) " +
+		"SELECT tender_id, $2::text, description, service_type, submission_deadline, $3::numeric, $4::text, enforce_budget, version + 1 " +
+		"FROM tender_version WHERE tender_id = $1 ORDER BY version DESC LIMIT 1;"
+	if got := squash(c.query()); got != want {
+		t.Errorf("query:\n got %s\nwant %s", got, want)
+	}
+	// arguments follow the order of the columns, as the placeholders do
+	if len(c.args) != 3 {
+		t.Fatalf("args = %v", c.args)
+	}
+	if s, ok := c.args[0].(*string); !ok || *s != "name" {
+		t.Errorf("name arg = %#v", c.args[0])
+	}
+	if s, ok := c.args[1].(*string); !ok || *s != "100.50" {
+		t.Errorf("budget arg = %#v", c.args[1])
+	}
+	if s, ok := c.args[2].(*string); !ok || s != nil {
+		t.Errorf("budget_currency arg = %#v, want nil to clear it", c.args[2])
+	}
+}
+
+func TestVersionCopyPlaceholdersFollowSetOrder(t *testing.T) {
+	c := newVersionCopy(_bidVersions).set("currency", "RUB").set("name", "bid")
+
+	want := "INSERT INTO bid_version (bid_id, name, description, price, currency, version) " +
+		"SELECT bid_id, $3::text, description, price, $2::text, version + 1 " +
+		"FROM bid_version WHERE bid_id = $1 ORDER BY version DESC LIMIT 1;"
+	if got := squash(c.query()); got != want {
+		t.Errorf("query:\n got %s\nwant %s", got, want)
+	}
+	if !reflect.DeepEqual(c.args, []any{"RUB", "bid"}) {
+		t.Errorf("args = %#v", c.args)
+	}
+}
+
+func TestVersionCopyWithoutChanges(t *testing.T) {
+	c := newVersionCopy(_lotVersions)
+
+	want := "INSERT INTO lot_version (lot_id, name, description, service_type, version) " +
+		"SELECT lot_id, name, description, service_type, version + 1 " +
+		"FROM lot_version WHERE lot_id = $1 ORDER BY version DESC LIMIT 1;"
+	if got := squash(c.query()); got != want {
+		t.Errorf("query:\n got %s\nwant %s", got, want)
+	}
+	if len(c.args) != 0 {
+		t.Errorf("args = %#v", c.args)
+	}
+}
+
+func TestVersionCopyUnknownColumn(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a panic for a column missing from the table")
+		}
+	}()
+	newVersionCopy(_tenderVersions).set("name = 'x'; --", "value")
+}
+
+// TestEditRoundTrip decodes PATCH bodies with hostile values and checks that
+// the new version gets them verbatim as parameters, while the SQL text stays
+// the same as for harmless values.
+func TestEditRoundTrip(t *testing.T) {
+	for _, value := range _hostileValues {
+		body, _ := json.Marshal(map[string]string{"name": value, "description": value})
+		var patch struct {
+			Name        model.Optional[string] `json:"name"`
+			Description model.Optional[string] `json:"description"`
+		}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			t.Fatalf("unmarshal %q: %v", value, err)
+		}
+
+		t.Run("tender/"+value, func(t *testing.T) {
+			c := tenderEdit(EditTenderInput{Name: patch.Name, Description: patch.Description})
+			plain := tenderEdit(EditTenderInput{
+				Name:        model.Optional[string]{Set: true, Value: "plain"},
+				Description: model.Optional[string]{Set: true, Value: "plain"},
+			})
+			checkExec(t, c, plain.query(), []driver.Value{_ownerID, value, value})
+		})
+		t.Run("bid/"+value, func(t *testing.T) {
+			c := bidEdit(EditBidInput{Name: patch.Name, Description: patch.Description})
+			plain := bidEdit(EditBidInput{
+				Name:        model.Optional[string]{Set: true, Value: "plain"},
+				Description: model.Optional[string]{Set: true, Value: "plain"},
+			})
+			checkExec(t, c, plain.query(), []driver.Value{_ownerID, value, value})
+		})
+	}
+}
+
+// TestBumpVersionCarriesAttachments checks that a status change, such as
+// publishing a tender with an attachment, gives the new version the
+// attachment set of the previous one.
+func TestBumpVersionCarriesAttachments(t *testing.T) {
+	conn := &recordingConn{}
+	db := sqlx.NewDb(sql.OpenDB(conn), "pgx")
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err = bumpVersion(context.Background(), tx, _tenderVersions, _tenderAttachments, _ownerID); err != nil {
+		t.Fatalf("bump: %v", err)
+	}
+
+	if len(conn.execs) != 2 {
+		t.Fatalf("executed %d statements, want the version copy and the attachment carry", len(conn.execs))
+	}
+	if got, want := conn.execs[0].query, newVersionCopy(_tenderVersions).query(); got != want {
+		t.Errorf("version copy:\n got %s\nwant %s", got, want)
+	}
+	want := "INSERT INTO tender_attachment (tender_id, version, attachment_id) " +
+		"SELECT tender_id, version + 1, attachment_id FROM tender_attachment " +
+		"WHERE tender_id = $1 AND version = (SELECT MAX(version) - 1 FROM tender_version WHERE tender_id = $1);"
+	if got := squash(conn.execs[1].query); got != want {
+		t.Errorf("attachment carry:\n got %s\nwant %s", got, want)
+	}
+	for i, e := range conn.execs {
+		if !reflect.DeepEqual(e.args, []driver.Value{_ownerID}) {
+			t.Errorf("statement %d args = %#v", i, e.args)
+		}
+	}
+}
+
+func checkExec(t *testing.T, c *versionCopy, query string, args []driver.Value) {
+	t.Helper()
+	conn := &recordingConn{}
+	db := sqlx.NewDb(sql.OpenDB(conn), "pgx")
+	defer db.Close()
+
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+	if err = c.exec(context.Background(), tx, _ownerID); err != nil {
+		t.Fatalf("exec: %v", err)
+	}
+	if len(conn.execs) != 1 {
+		t.Fatalf("executed %d statements", len(conn.execs))
+	}
+	if conn.execs[0].query != query {
+		t.Errorf("query:\n got %s\nwant %s", conn.execs[0].query, query)
+	}
+	if !reflect.DeepEqual(conn.execs[0].args, args) {
+		t.Errorf("args = %#v, want %#v", conn.execs[0].args, args)
+	}
+}
+
+type recordedExec struct {
+	query string
+	args  []driver.Value
+}
+
+// recordingConn is a database connection that records executed statements.
+type recordingConn struct {
+	execs []recordedExec
+}
+
+func (c *recordingConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *recordingConn) Driver() driver.Driver                        { return nil }
+func (c *recordingConn) Close() error                                 { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error)                    { return c, nil }
+func (c *recordingConn) Commit() error                                { return nil }
+func (c *recordingConn) Rollback() error                              { return nil }
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	values := make([]driver.Value, 0, len(args))
+	for _, a := range args {
+		values = append(values, a.Value)
+	}
+	c.execs = append(c.execs, recordedExec{query: query, args: values})
+	return driver.RowsAffected(1), nil
+}
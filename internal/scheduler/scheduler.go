@@ -0,0 +1,62 @@
+package scheduler
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/b0pof/avito-internship/internal/config"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+const _defaultInterval = time.Minute
+
+type TenderCloser interface {
+	CloseExpiredTenders(ctx context.Context) (int, error)
+}
+
+// Scheduler periodically closes tenders whose submission deadline has passed.
+type Scheduler struct {
+	interval time.Duration
+	closer   TenderCloser
+	logger   *slog.Logger
+}
+
+func New(cfg config.Scheduler, closer TenderCloser, log *slog.Logger) *Scheduler {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = _defaultInterval
+	}
+	return &Scheduler{
+		interval: interval,
+		closer:   closer,
+		logger:   log,
+	}
+}
+
+// Run blocks until ctx is canceled.
+func (s *Scheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	ctx = logger.WithContext(ctx, s.logger.With(slog.String("component", "scheduler")))
+	for {
+		s.tick(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context) {
+	closed, err := s.closer.CloseExpiredTenders(ctx)
+	if err != nil {
+		logger.Error(ctx, "closing expired tenders: "+err.Error())
+		return
+	}
+	if closed > 0 {
+		logger.Info(ctx, "expired tenders closed", slog.Int("count", closed))
+	}
+}
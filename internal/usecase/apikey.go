@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type CreateAPIKeyInput struct {
+	OrganizationID string `json:"-"`
+	Name           string `json:"name" validate:"required,max=100"`
+}
+
+func (u *Usecase) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (model.IssuedAPIKey, error) {
+	userID, err := u.keyManagerID(ctx, input.OrganizationID)
+	if err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	created, err := u.repo.CreateAPIKey(ctx, repository.CreateAPIKeyInput{
+		OrganizationID: input.OrganizationID,
+		Name:           input.Name,
+		Prefix:         prefix,
+		KeyHash:        auth.HashAPIKey(key),
+		CreatedBy:      userID,
+	})
+	if err != nil {
+		return model.IssuedAPIKey{}, err
+	}
+	return model.IssuedAPIKey{
+		APIKey: created,
+		Key:    key,
+	}, nil
+}
+
+type GetAPIKeysInput struct {
+	OrganizationID string
+}
+
+func (u *Usecase) GetAPIKeys(ctx context.Context, input GetAPIKeysInput) ([]model.APIKey, error) {
+	if _, err := u.keyManagerID(ctx, input.OrganizationID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetOrganizationAPIKeys(ctx, input.OrganizationID)
+}
+
+type RevokeAPIKeyInput struct {
+	OrganizationID string
+	KeyID          string
+}
+
+func (u *Usecase) RevokeAPIKey(ctx context.Context, input RevokeAPIKeyInput) (model.APIKey, error) {
+	if _, err := u.keyManagerID(ctx, input.OrganizationID); err != nil {
+		return model.APIKey{}, err
+	}
+	return u.repo.RevokeAPIKey(ctx, repository.RevokeAPIKeyInput{
+		OrganizationID: input.OrganizationID,
+		KeyID:          input.KeyID,
+	})
+}
+
+// keyManagerID checks that the caller is an employee responsible for the
+// organization. API keys cannot manage other keys.
+func (u *Usecase) keyManagerID(ctx context.Context, orgID string) (string, error) {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	if p.IsAPIKey() {
+		return "", model.ErrNoRights.Wrap("api_key_manages_keys")
+	}
+	if !u.can(ctx, orgID, policy.ActionManageAPIKeys) {
+		return "", model.ErrNoRights
+	}
+	return p.UserID, nil
+}
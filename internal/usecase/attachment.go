@@ -0,0 +1,340 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+	"github.com/b0pof/avito-internship/pkg/logger"
+)
+
+// BlobStorage keeps attachment contents under opaque keys.
+type BlobStorage interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+const (
+	_maxAttachmentSize     = 20 << 20
+	_maxAttachmentFilename = 255
+)
+
+// _attachmentTypes maps the allowed content types to the type sniffed from
+// the first bytes of the file, which has to match the declared one.
+var _attachmentTypes = map[string]string{
+	"application/pdf":    "application/pdf",
+	"application/msword": _compoundFileType,
+	"application/vnd.openxmlformats-officedocument.wordprocessingml.document": "application/zip",
+	"application/vnd.ms-excel": _compoundFileType,
+	"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet": "application/zip",
+	"application/zip": "application/zip",
+	"image/jpeg":      "image/jpeg",
+	"image/png":       "image/png",
+	"text/plain":      "text/plain",
+	"text/csv":        "text/plain",
+}
+
+// _compoundFileType is the sniffed type of legacy Office documents, which
+// http.DetectContentType does not recognize.
+const _compoundFileType = "application/x-ole-storage"
+
+var _compoundFileMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// _sniffLen is how many first bytes http.DetectContentType considers.
+const _sniffLen = 512
+
+// sniffContentType returns the media type of the content by its first bytes.
+func sniffContentType(head []byte) string {
+	if bytes.HasPrefix(head, _compoundFileMagic) {
+		return _compoundFileType
+	}
+	sniffed, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	return sniffed
+}
+
+type AddAttachmentInput struct {
+	OwnerID          string
+	Filename         string
+	ContentType      string
+	Body             io.Reader
+	ExpectedVersions []int
+}
+
+type RemoveAttachmentInput struct {
+	OwnerID          string
+	AttachmentID     string
+	ExpectedVersions []int
+}
+
+type GetAttachmentsInput struct {
+	OwnerID string
+	Version int
+}
+
+type GetAttachmentInput struct {
+	OwnerID      string
+	AttachmentID string
+}
+
+// AttachmentContent is an attachment opened for reading; the caller closes Body.
+type AttachmentContent struct {
+	model.Attachment
+	Body io.ReadCloser
+}
+
+func (u *Usecase) AddTenderAttachment(ctx context.Context, input AddAttachmentInput) (model.Tender, error) {
+	userID, err := currentAuthorID(ctx)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	if !u.repo.TenderExists(ctx, input.OwnerID) {
+		return model.Tender{}, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.OwnerID, policy.ActionEditTender) {
+		return model.Tender{}, model.ErrNoRights
+	}
+	attachment, err := u.storeBlob(ctx, userID, input)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	tender, err := u.repo.AddTenderAttachment(ctx, repository.AddAttachmentInput{
+		OwnerID:          input.OwnerID,
+		Attachment:       attachment,
+		ExpectedVersions: input.ExpectedVersions,
+	})
+	if err != nil {
+		u.dropBlob(ctx, attachment.StorageKey)
+		return model.Tender{}, err
+	}
+	return tender, nil
+}
+
+func (u *Usecase) RemoveTenderAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Tender, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Tender{}, err
+	}
+	if !u.repo.TenderExists(ctx, input.OwnerID) {
+		return model.Tender{}, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.OwnerID, policy.ActionEditTender) {
+		return model.Tender{}, model.ErrNoRights
+	}
+	return u.repo.RemoveTenderAttachment(ctx, repository.RemoveAttachmentInput(input))
+}
+
+func (u *Usecase) GetTenderAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error) {
+	if err := u.checkTenderAttachmentsVisible(ctx, input.OwnerID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetTenderAttachments(ctx, repository.GetAttachmentsInput(input))
+}
+
+func (u *Usecase) OpenTenderAttachment(ctx context.Context, input GetAttachmentInput) (AttachmentContent, error) {
+	if err := u.checkTenderAttachmentsVisible(ctx, input.OwnerID); err != nil {
+		return AttachmentContent{}, err
+	}
+	attachment, err := u.repo.GetTenderAttachment(ctx, input.OwnerID, input.AttachmentID)
+	if err != nil {
+		return AttachmentContent{}, err
+	}
+	return u.openBlob(ctx, attachment)
+}
+
+func (u *Usecase) checkTenderAttachmentsVisible(ctx context.Context, tenderID string) error {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return model.ErrNoRights
+	}
+	return nil
+}
+
+func (u *Usecase) AddBidAttachment(ctx context.Context, input AddAttachmentInput) (model.Bid, error) {
+	userID, err := u.checkBidAuthor(ctx, input.OwnerID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	attachment, err := u.storeBlob(ctx, userID, input)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	bid, err := u.repo.AddBidAttachment(ctx, repository.AddAttachmentInput{
+		OwnerID:          input.OwnerID,
+		Attachment:       attachment,
+		ExpectedVersions: input.ExpectedVersions,
+	})
+	if err != nil {
+		u.dropBlob(ctx, attachment.StorageKey)
+		return model.Bid{}, err
+	}
+	return bid, nil
+}
+
+func (u *Usecase) RemoveBidAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Bid, error) {
+	if _, err := u.checkBidAuthor(ctx, input.OwnerID); err != nil {
+		return model.Bid{}, err
+	}
+	return u.repo.RemoveBidAttachment(ctx, repository.RemoveAttachmentInput(input))
+}
+
+func (u *Usecase) GetBidAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error) {
+	if err := u.checkBidAttachmentsVisible(ctx, input.OwnerID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetBidAttachments(ctx, repository.GetAttachmentsInput(input))
+}
+
+func (u *Usecase) OpenBidAttachment(ctx context.Context, input GetAttachmentInput) (AttachmentContent, error) {
+	if err := u.checkBidAttachmentsVisible(ctx, input.OwnerID); err != nil {
+		return AttachmentContent{}, err
+	}
+	attachment, err := u.repo.GetBidAttachment(ctx, input.OwnerID, input.AttachmentID)
+	if err != nil {
+		return AttachmentContent{}, err
+	}
+	return u.openBlob(ctx, attachment)
+}
+
+// checkBidAuthor allows changing bid attachments to the bid author only.
+func (u *Usecase) checkBidAuthor(ctx context.Context, bidID string) (string, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !u.repo.BidExists(ctx, bidID) {
+		return "", model.ErrNoBidFound
+	}
+	authorID, err := u.repo.GetUserIDByBidID(ctx, bidID)
+	if err != nil {
+		return "", err
+	}
+	if userID != authorID {
+		return "", model.ErrNoRights
+	}
+	return userID, nil
+}
+
+func (u *Usecase) checkBidAttachmentsVisible(ctx context.Context, bidID string) error {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return err
+	}
+	if !u.repo.BidExists(ctx, bidID) {
+		return model.ErrNoBidFound
+	}
+	visible, err := u.isBidVisible(ctx, bidID)
+	if err != nil {
+		return err
+	}
+	if !visible {
+		return model.ErrNoRights
+	}
+	return nil
+}
+
+// storeBlob validates the upload and writes it to the blob storage, computing
+// its size and checksum on the way.
+func (u *Usecase) storeBlob(ctx context.Context, authorID string, input AddAttachmentInput) (repository.NewAttachment, error) {
+	filename := filepath.Base(input.Filename)
+	if input.Filename == "" || filename != input.Filename || utf8.RuneCountInString(filename) > _maxAttachmentFilename {
+		return repository.NewAttachment{}, model.ErrInvalidAttributeValue.Wrap("invalid_file_name")
+	}
+	contentType, _, err := mime.ParseMediaType(input.ContentType)
+	if err != nil {
+		return repository.NewAttachment{}, model.ErrAttachmentType
+	}
+	sniffed, ok := _attachmentTypes[contentType]
+	if !ok {
+		return repository.NewAttachment{}, model.ErrAttachmentType
+	}
+	// the declared type is only trusted if the content starts like it
+	head := make([]byte, _sniffLen)
+	n, err := io.ReadFull(input.Body, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return repository.NewAttachment{}, model.ErrInvalidBody
+	}
+	head = head[:n]
+	if sniffContentType(head) != sniffed {
+		return repository.NewAttachment{}, model.ErrAttachmentType
+	}
+	key, err := newBlobKey()
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return repository.NewAttachment{}, model.ErrInternal
+	}
+
+	hash := sha256.New()
+	counter := &byteCounter{}
+	// one byte over the limit is enough to tell the upload is too large
+	content := io.MultiReader(bytes.NewReader(head), input.Body)
+	body := io.TeeReader(io.LimitReader(content, _maxAttachmentSize+1), io.MultiWriter(hash, counter))
+	if err = u.blobs.Put(ctx, key, body); err != nil {
+		logger.Error(ctx, err.Error())
+		return repository.NewAttachment{}, model.ErrInternal
+	}
+	if counter.n > _maxAttachmentSize {
+		u.dropBlob(ctx, key)
+		return repository.NewAttachment{}, model.ErrAttachmentTooLarge
+	}
+	return repository.NewAttachment{
+		StorageKey:  key,
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        counter.n,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		AuthorID:    authorID,
+	}, nil
+}
+
+func (u *Usecase) openBlob(ctx context.Context, attachment model.Attachment) (AttachmentContent, error) {
+	body, err := u.blobs.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		logger.Error(ctx, err.Error())
+		return AttachmentContent{}, model.ErrInternal
+	}
+	return AttachmentContent{
+		Attachment: attachment,
+		Body:       body,
+	}, nil
+}
+
+// dropBlob removes a blob that was not recorded; a failure only leaves garbage.
+func (u *Usecase) dropBlob(ctx context.Context, key string) {
+	if err := u.blobs.Delete(ctx, key); err != nil {
+		logger.Error(ctx, err.Error())
+	}
+}
+
+func newBlobKey() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+type byteCounter struct {
+	n int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
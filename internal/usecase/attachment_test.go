@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+)
+
+// memoryBlobs is a BlobStorage keeping contents in memory.
+type memoryBlobs map[string][]byte
+
+func (m memoryBlobs) Put(_ context.Context, key string, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	m[key] = b
+	return err
+}
+
+func (m memoryBlobs) Open(_ context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m[key])), nil
+}
+
+func (m memoryBlobs) Delete(_ context.Context, key string) error {
+	delete(m, key)
+	return nil
+}
+
+func TestStoreBlobSniffsContent(t *testing.T) {
+	png := "\x89PNG\r\n\x1a\n" + strings.Repeat("x", 1000)
+	doc := string(_compoundFileMagic) + strings.Repeat("\x00", 600)
+	tests := []struct {
+		name        string
+		contentType string
+		content     string
+		err         error
+	}{
+		{name: "png", contentType: "image/png", content: png},
+		{name: "pdf", contentType: "application/pdf", content: "%PDF-1.7\n"},
+		{name: "docx", contentType: "application/vnd.openxmlformats-officedocument.wordprocessingml.document", content: "PK\x03\x04rest"},
+		{name: "doc", contentType: "application/msword", content: doc},
+		{name: "csv", contentType: "text/csv; charset=utf-8", content: "a;b\n1;2\n"},
+		{name: "empty text", contentType: "text/plain", content: ""},
+		{name: "html as png", contentType: "image/png", content: "<html><script>alert(1)</script></html>", err: model.ErrAttachmentType},
+		{name: "executable as pdf", contentType: "application/pdf", content: "MZ\x90\x00\x03", err: model.ErrAttachmentType},
+		{name: "png as text", contentType: "text/plain", content: png, err: model.ErrAttachmentType},
+		{name: "zip as doc", contentType: "application/msword", content: "PK\x03\x04rest", err: model.ErrAttachmentType},
+		{name: "html", contentType: "text/html", content: "<html></html>", err: model.ErrAttachmentType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobs := memoryBlobs{}
+			u := New(nil, nil, blobs)
+			a, err := u.storeBlob(context.Background(), "", AddAttachmentInput{
+				Filename:    "file",
+				ContentType: tt.contentType,
+				Body:        strings.NewReader(tt.content),
+			})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				if len(blobs) != 0 {
+					t.Error("rejected upload is stored")
+				}
+				return
+			}
+			// the sniffed bytes are put back in front of the rest
+			if got := string(blobs[a.StorageKey]); got != tt.content {
+				t.Errorf("stored %d bytes, want %d", len(got), len(tt.content))
+			}
+			if a.Size != int64(len(tt.content)) {
+				t.Errorf("size = %d, want %d", a.Size, len(tt.content))
+			}
+		})
+	}
+}
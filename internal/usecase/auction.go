@@ -0,0 +1,87 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/money"
+)
+
+// _maxAuctionExtension caps the auto-extension of an auction, in minutes.
+const _maxAuctionExtension = 24 * 60
+
+type GetAuctionInput struct {
+	TenderID string
+	// LotID selects the lot auction of a tender split into lots.
+	LotID string
+}
+
+func (u *Usecase) GetAuction(ctx context.Context, input GetAuctionInput) (model.Auction, error) {
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return model.Auction{}, err
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return model.Auction{}, model.ErrNoRights
+	}
+	if !tender.Auction {
+		return model.Auction{}, model.ErrInvalidAttributeValue.Wrap("tender_not_auction")
+	}
+	var lotID *string
+	if input.LotID != "" {
+		lotID = &input.LotID
+	}
+	return u.repo.GetAuction(ctx, tender, lotID)
+}
+
+// validateAuction checks the auction terms of a new tender. The auction ends
+// at the submission deadline and starts from the budget in its currency.
+func validateAuction(input CreateTenderInput) error {
+	if !input.Auction {
+		if input.AuctionStart != nil || input.AuctionStep != nil || input.AuctionExtension != 0 {
+			return model.ErrInvalidAttributeValue.Wrap("auction_terms_only")
+		}
+		return nil
+	}
+	if input.SubmissionDeadline == nil || input.Budget == nil {
+		return model.ErrInvalidAttributeValue.Wrap("auction_terms_required")
+	}
+	if input.Sealed {
+		return model.ErrInvalidAttributeValue.Wrap("auction_sealed")
+	}
+	if input.AuctionStep == nil || !money.IsAmount(*input.AuctionStep) {
+		return model.ErrInvalidAttributeValue.Wrap("auction_step")
+	}
+	if input.AuctionStart != nil && !input.AuctionStart.Before(*input.SubmissionDeadline) {
+		return model.ErrInvalidAttributeValue.Wrap("auction_starts_late")
+	}
+	if input.AuctionExtension < 0 || input.AuctionExtension > _maxAuctionExtension {
+		return model.ErrInvalidAttributeValue.Wrap("auction_extension")
+	}
+	return nil
+}
+
+// checkAuctionPrice requires bids on an auction tender to be priced in the
+// currency of its budget. The step is checked by the repository, under lock.
+func checkAuctionPrice(tender model.Tender, price *model.Amount, currency *string) error {
+	if !tender.Auction {
+		return nil
+	}
+	if price == nil || currency == nil {
+		return model.ErrInvalidAttributeValue.Wrap("auction_price_required")
+	}
+	if tender.BudgetCurrency != nil && *currency != *tender.BudgetCurrency {
+		return model.ErrInvalidAttributeValue.Wrap("auction_currency_mismatch")
+	}
+	return nil
+}
+
+// checkAuctionRollback forbids rolling back bids once the auction has
+// started, since a rollback may raise the price.
+func checkAuctionRollback(tender model.Tender) error {
+	if tender.AuctionStarted(time.Now()) {
+		return model.ErrNoRights.Wrap("auction_rollback")
+	}
+	return nil
+}
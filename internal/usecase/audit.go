@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+func (u *Usecase) GetAuditEvents(ctx context.Context, input repository.GetAuditEventsInput) ([]model.AuditEvent, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+
+	var orgID string
+	var err error
+	switch input.EntityType {
+	case repository.AuditEntityTender:
+		orgID, err = u.repo.GetTenderOrganizationID(ctx, input.EntityID)
+	case repository.AuditEntityBid:
+		orgID, err = u.repo.GetBidTenderOrganizationID(ctx, input.EntityID)
+		if err == nil {
+			var sealed bool
+			if sealed, err = u.isBidSealed(ctx, input.EntityID); err == nil && sealed {
+				return nil, model.ErrBidsSealed
+			}
+		}
+	case repository.AuditEntityOrganization:
+		orgID = input.EntityID
+	default:
+		return nil, model.ErrInvalidAttributeValue
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.can(ctx, orgID, policy.ActionViewAudit) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetAuditEvents(ctx, input)
+}
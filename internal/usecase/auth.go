@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+)
+
+type TokenIssuer interface {
+	Issue(user model.Employee) (model.AuthToken, error)
+}
+
+type LoginInput struct {
+	Username string `json:"username" validate:"required,max=50"`
+	Password string `json:"password" validate:"required"`
+}
+
+func (u *Usecase) Login(ctx context.Context, input LoginInput) (model.AuthToken, error) {
+	creds, err := u.repo.GetEmployeeCredentials(ctx, input.Username)
+	if err != nil || creds.PasswordHash == "" {
+		return model.AuthToken{}, model.ErrInvalidCredentials
+	}
+	if err = bcrypt.CompareHashAndPassword([]byte(creds.PasswordHash), []byte(input.Password)); err != nil {
+		return model.AuthToken{}, model.ErrInvalidCredentials
+	}
+	return u.tokens.Issue(model.Employee{
+		ID:       creds.ID,
+		Username: creds.Username,
+	})
+}
+
+// AuthenticateAPIKey resolves an organization API key into a principal acting
+// for the organization. Employee that issued the key is recorded as the author
+// of changes made with it, but the key does not act as that employee.
+func (u *Usecase) AuthenticateAPIKey(ctx context.Context, key string) (model.Principal, error) {
+	owner, err := u.repo.UseAPIKey(ctx, auth.HashAPIKey(key))
+	if err != nil {
+		return model.Principal{}, err
+	}
+	return model.Principal{
+		OrganizationID: owner.OrganizationID,
+		APIKeyID:       owner.ID,
+		KeyCreatorID:   owner.CreatedBy,
+	}, nil
+}
+
+// currentPrincipal returns the caller authenticated by the auth middleware.
+func currentPrincipal(ctx context.Context) (model.Principal, error) {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return model.Principal{}, model.ErrUserNotFound.Wrap("authorization_required")
+	}
+	return p, nil
+}
+
+// currentUserID returns ID of the employee the caller acts as. API keys act
+// for the organization only, so personal operations are refused to them.
+func currentUserID(ctx context.Context) (string, error) {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	if p.IsAPIKey() {
+		return "", model.ErrNoRights.Wrap("api_key_not_personal")
+	}
+	return p.UserID, nil
+}
+
+// currentAuthorID returns ID of the employee recorded as the author of an
+// organization action: the caller or, for an API key, the employee who issued it.
+func currentAuthorID(ctx context.Context) (string, error) {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return "", err
+	}
+	if p.IsAPIKey() {
+		return p.KeyCreatorID, nil
+	}
+	return p.UserID, nil
+}
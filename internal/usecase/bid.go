@@ -2,162 +2,238 @@ package usecase
 
 import (
 	"context"
-
-	"github.com/pkg/errors"
+	"time"
 
 	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/money"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
 	"github.com/b0pof/avito-internship/internal/repository"
 )
 
 func (u *Usecase) CreateBid(ctx context.Context, input repository.CreateBidInput) (model.Bid, error) {
-	if !u.repo.IsUserExist(ctx, input.AuthorID) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if !u.repo.IsUserExist(ctx, userID) {
 		return model.Bid{}, model.ErrUserNotFound
 	}
+	input.AuthorID = userID
+	if err = money.ValidatePrice(input.Price, input.Currency); err != nil {
+		return model.Bid{}, err
+	}
 	if input.AuthorType == "Organization" {
-		_, err := u.repo.GetOrganizationIDByEmployeeID(ctx, input.AuthorID)
-		if err != nil {
-			return model.Bid{}, errors.Wrap(err, "невозможно созодать предложение от имени организации")
+		if _, err = u.repo.GetOrganizationIDByEmployeeID(ctx, input.AuthorID); err != nil {
+			return model.Bid{}, model.ErrNoOrganizationFound.Wrap("organization_bid")
 		}
 	}
-	status, err := u.repo.GetTenderStatus(ctx, input.TenderID)
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
 	if err != nil {
 		return model.Bid{}, err
 	}
-	if status != "Published" {
-		return model.Bid{}, errors.Wrap(model.ErrNoRights, "тендер не опубликован")
+	if tender.Status != model.TenderStatusPublished {
+		return model.Bid{}, model.ErrNoRights.Wrap("tender_not_published")
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return model.Bid{}, model.ErrNoRights.Wrap("tender_invite_only")
+	}
+	if tender.SubmissionClosed(time.Now()) {
+		return model.Bid{}, model.ErrSubmissionClosed
+	}
+	if err = u.checkBidLot(ctx, input.TenderID, input.LotID); err != nil {
+		return model.Bid{}, err
+	}
+	if err = money.CheckBudget(tender, input.Price, input.Currency); err != nil {
+		return model.Bid{}, err
+	}
+	if err = checkAuctionPrice(tender, input.Price, input.Currency); err != nil {
+		return model.Bid{}, err
 	}
 	return u.repo.CreateBid(ctx, input)
 }
 
 type GetMyBidsInput struct {
-	Limit    int
-	Offset   int
-	Username string
+	repository.Pagination
 }
 
-func (u *Usecase) GetMyBids(ctx context.Context, input GetMyBidsInput) ([]model.Bid, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+func (u *Usecase) GetMyBids(ctx context.Context, input GetMyBidsInput) (model.Page[model.Bid], error) {
+	userID, err := currentUserID(ctx)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Bid]{}, err
 	}
 	bids, err := u.repo.GetMyBids(ctx, repository.GetMyBidsInput{
-		Limit:  input.Limit,
-		Offset: input.Offset,
-		UserID: userID,
+		UserID:     userID,
+		Pagination: input.Pagination,
 	})
 	if err != nil {
-		return nil, err
+		return model.Page[model.Bid]{}, err
 	}
 	return bids, nil
 }
 
-func (u *Usecase) GetTenderBids(ctx context.Context, input repository.GetTenderBidsInput) ([]model.Bid, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+func (u *Usecase) GetTenderBids(ctx context.Context, input repository.GetTenderBidsInput) (model.TenderBids, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.TenderBids{}, err
+	}
+	if input.Keyset && input.Sort != "" && input.Sort != repository.BidSortName {
+		return model.TenderBids{}, validate.Field(model.ErrInvalidQueryParam, "sort", "cursor_sort")
+	}
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
 	if err != nil {
-		return nil, err
+		return model.TenderBids{}, err
 	}
-	if !u.repo.IsTenderExist(ctx, input.TenderID) {
-		return nil, model.ErrTenderNotFound
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewBids) {
+		return model.TenderBids{}, model.ErrNoRights
+	}
+	if tender.BidsSealed(time.Now()) {
+		count, err := u.repo.CountTenderBids(ctx, repository.CountTenderBidsInput{
+			TenderID: input.TenderID,
+			LotID:    input.LotID,
+		})
+		if err != nil {
+			return model.TenderBids{}, err
+		}
+		return model.TenderBids{
+			Sealed: true,
+			Count:  count,
+		}, nil
 	}
 	bids, err := u.repo.GetTenderBids(ctx, input)
 	if err != nil {
-		return nil, err
-	}
-	if !u.repo.IsUserResponsibleForTender(ctx, input.TenderID, userID) {
-		return nil, model.ErrNoRights
+		return model.TenderBids{}, err
 	}
-	return bids, nil
+	return model.TenderBids{
+		Page:  bids,
+		Count: len(bids.Items),
+	}, nil
 }
 
 type GetBidStatusInput struct {
-	BidID    string
-	Username string
+	BidID string
 }
 
-func (u *Usecase) GetBidStatus(ctx context.Context, input GetBidStatusInput) (string, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
-	if err != nil {
-		return "", err
+// GetBidStatus returns the bid, whose status is reported along with the
+// version for the ETag.
+func (u *Usecase) GetBidStatus(ctx context.Context, input GetBidStatusInput) (model.Bid, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Bid{}, err
 	}
-	status, err := u.repo.GetBidStatus(ctx, input.BidID)
+	bid, err := u.repo.GetBidByID(ctx, input.BidID)
 	if err != nil {
-		return "", err
+		return model.Bid{}, err
 	}
-	hasAccess, err := u.repo.IsBidVisibleForUser(ctx, userID, input.BidID)
+	hasAccess, err := u.isBidVisible(ctx, input.BidID)
 	if err != nil {
-		return "", err
+		return model.Bid{}, err
 	}
 	if !hasAccess {
-		return "", model.ErrNoRights
+		return model.Bid{}, model.ErrNoRights
 	}
-	return status, nil
+	return bid, nil
 }
 
 type UpdateBidStatusInput struct {
-	BidID    string
-	Status   string
-	Username string
+	BidID            string
+	Status           string
+	ExpectedVersions []int
 }
 
 func (u *Usecase) UpdateBidStatus(ctx context.Context, input UpdateBidStatusInput) (model.Bid, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+	p, err := currentPrincipal(ctx)
 	if err != nil {
 		return model.Bid{}, err
 	}
-	if !u.repo.BidExists(ctx, input.BidID) {
-		return model.Bid{}, model.ErrNoBidFound
+	bid, err := u.repo.GetBidByID(ctx, input.BidID)
+	if err != nil {
+		return model.Bid{}, err
 	}
-	hasAccess, err := u.repo.IsBidVisibleForUser(ctx, userID, input.BidID)
+	rule, err := bidTransition(bid.Status, input.Status)
 	if err != nil {
 		return model.Bid{}, err
 	}
-	if !hasAccess {
+	isAuthor := rule.Author && !p.IsAPIKey() && bid.AuthorID == p.UserID
+	if !isAuthor && (rule.Action == "" || !u.canOnBid(ctx, input.BidID, rule.Action)) {
 		return model.Bid{}, model.ErrNoRights
 	}
+	if input.Status == model.BidStatusPublished {
+		// bids can be published only while the tender accepts them
+		tenderID, err := u.repo.GetBidTenderID(ctx, input.BidID)
+		if err != nil {
+			return model.Bid{}, err
+		}
+		tender, err := u.repo.GetTenderByID(ctx, tenderID)
+		if err != nil {
+			return model.Bid{}, err
+		}
+		if tender.Status != model.TenderStatusPublished {
+			return model.Bid{}, model.ErrIllegalTransition.Wrap("tender_not_published")
+		}
+		if tender.SubmissionClosed(time.Now()) {
+			return model.Bid{}, model.ErrSubmissionClosed
+		}
+	}
 	return u.repo.UpdateBidStatus(ctx, repository.UpdateBidStatusInput{
-		Status: input.Status,
-		BidID:  input.BidID,
+		BidID:            input.BidID,
+		FromStatus:       bid.Status,
+		Status:           input.Status,
+		ExpectedVersions: input.ExpectedVersions,
 	})
 }
 
 type SubmitDecisionInput struct {
 	BidID    string
-	Username string
 	Decision string
 }
 
-func (u *Usecase) SubmitDecision(ctx context.Context, input SubmitDecisionInput) (model.Bid, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+func (u *Usecase) SubmitDecision(ctx context.Context, input SubmitDecisionInput) (model.BidDecisionResult, error) {
+	userID, err := currentAuthorID(ctx)
 	if err != nil {
-		return model.Bid{}, err
+		return model.BidDecisionResult{}, err
 	}
-	if !u.repo.UserCanSubmitDecision(ctx, input.BidID, userID) {
-		return model.Bid{}, model.ErrNoRights
+	if !u.repo.BidExists(ctx, input.BidID) {
+		return model.BidDecisionResult{}, model.ErrNoBidFound
 	}
-	if input.Decision == "Approved" {
-		if err := u.repo.CloseTenderByBidID(ctx, input.BidID); err != nil {
-			return model.Bid{}, err
-		}
-		return u.repo.GetBidByID(ctx, input.BidID)
-	} else if input.Decision == "Rejected" {
-		return u.repo.UpdateBidStatus(ctx, repository.UpdateBidStatusInput{
-			Status: "Canceled",
-			BidID:  input.BidID,
-		})
-	} else {
-		return model.Bid{}, model.ErrWrongDecision
+	if !u.canOnBid(ctx, input.BidID, policy.ActionDecideBid) {
+		return model.BidDecisionResult{}, model.ErrNoRights
+	}
+	if input.Decision != "Approved" && input.Decision != "Rejected" {
+		return model.BidDecisionResult{}, model.ErrWrongDecision
+	}
+	tenderID, err := u.repo.GetBidTenderID(ctx, input.BidID)
+	if err != nil {
+		return model.BidDecisionResult{}, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return model.BidDecisionResult{}, err
 	}
+	if tender.Status == model.TenderStatusCreated {
+		return model.BidDecisionResult{}, model.ErrIllegalTransition.Wrap("tender_not_published")
+	}
+	if tender.BidsSealed(time.Now()) {
+		return model.BidDecisionResult{}, model.ErrBidsSealed
+	}
+	return u.repo.DecideBid(ctx, repository.DecideBidInput{
+		TenderID:     tenderID,
+		BidID:        input.BidID,
+		UserID:       userID,
+		Decision:     input.Decision,
+		DeciderRoles: policy.RolesAllowing(policy.ActionDecideBid),
+	})
 }
 
 type UpdateBidInput struct {
-	BidID       string
-	Username    string
-	Name        string
-	Description string
+	BidID            string
+	Name             model.Optional[string]
+	Description      model.Optional[string]
+	Price            model.Optional[model.Amount]
+	Currency         model.Optional[string]
+	ExpectedVersions []int
 }
 
 func (u *Usecase) UpdateBid(ctx context.Context, input UpdateBidInput) (model.Bid, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return model.Bid{}, err
 	}
@@ -171,21 +247,36 @@ func (u *Usecase) UpdateBid(ctx context.Context, input UpdateBidInput) (model.Bi
 	if userID != authorID {
 		return model.Bid{}, model.ErrNoRights
 	}
+	if err = checkRequired(input.Name, "bid_name_required"); err != nil {
+		return model.Bid{}, err
+	}
+	if input.Description.Cleared() {
+		return model.Bid{}, model.ErrInvalidAttributeValue.Wrap("field_not_null")
+	}
+	if err = checkPriceUpdate(input.Price, input.Currency); err != nil {
+		return model.Bid{}, err
+	}
+	if err = u.checkBidPrice(ctx, input); err != nil {
+		return model.Bid{}, err
+	}
 	return u.repo.UpdateBid(ctx, repository.EditBidInput{
-		BidID:       input.BidID,
-		Name:        input.Name,
-		Description: input.Description,
+		BidID:            input.BidID,
+		Name:             input.Name,
+		Description:      input.Description,
+		Price:            input.Price,
+		Currency:         input.Currency,
+		ExpectedVersions: input.ExpectedVersions,
 	})
 }
 
 type RollbackBidInput struct {
-	BidID    string
-	Version  int
-	Username string
+	BidID            string
+	Version          int
+	ExpectedVersions []int
 }
 
 func (u *Usecase) RollbackBid(ctx context.Context, input RollbackBidInput) (model.Bid, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+	userID, err := currentUserID(ctx)
 	if err != nil {
 		return model.Bid{}, err
 	}
@@ -209,8 +300,66 @@ func (u *Usecase) RollbackBid(ctx context.Context, input RollbackBidInput) (mode
 	if userID != authorID {
 		return model.Bid{}, model.ErrNoRights
 	}
+	tenderID, err := u.repo.GetBidTenderID(ctx, input.BidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if err = checkAuctionRollback(tender); err != nil {
+		return model.Bid{}, err
+	}
 	return u.repo.RollbackBid(ctx, repository.RollbackBidInput{
-		BidID:   input.BidID,
-		Version: input.Version,
+		BidID:            input.BidID,
+		Version:          input.Version,
+		ExpectedVersions: input.ExpectedVersions,
 	})
 }
+
+type GetBidVersionsInput struct {
+	BidID string
+}
+
+func (u *Usecase) GetBidVersions(ctx context.Context, input GetBidVersionsInput) ([]model.BidVersion, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	if !u.repo.BidExists(ctx, input.BidID) {
+		return nil, model.ErrNoBidFound
+	}
+	hasAccess, err := u.isBidVisible(ctx, input.BidID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasAccess {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetBidVersions(ctx, input.BidID)
+}
+
+// checkBidPrice checks the price the bid will have after the update against
+// the tender budget and auction terms.
+func (u *Usecase) checkBidPrice(ctx context.Context, input UpdateBidInput) error {
+	price, currency := input.Price.Ptr(), input.Currency.Ptr()
+	if !input.Price.Set {
+		bid, err := u.repo.GetBidByID(ctx, input.BidID)
+		if err != nil {
+			return err
+		}
+		price, currency = bid.Price, bid.Currency
+	}
+	tenderID, err := u.repo.GetBidTenderID(ctx, input.BidID)
+	if err != nil {
+		return err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if err = money.CheckBudget(tender, price, currency); err != nil {
+		return err
+	}
+	return checkAuctionPrice(tender, price, currency)
+}
@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/pkg/validate"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+const (
+	_maxCriteria        = 20
+	_maxCriterionWeight = 100
+	_maxScore           = 10
+	_rankingBidsLimit   = math.MaxInt32
+	_scorePrecision     = 100
+)
+
+type GetTenderCriteriaInput struct {
+	TenderID string
+}
+
+// GetTenderCriteria returns the criteria of a tender to anyone who may see it,
+// anonymous callers included, as in GetTenders, so bidders know how they are
+// evaluated.
+func (u *Usecase) GetTenderCriteria(ctx context.Context, input GetTenderCriteriaInput) ([]model.Criterion, error) {
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetTenderCriteria(ctx, input.TenderID)
+}
+
+type SetTenderCriteriaInput struct {
+	TenderID string
+	Criteria []model.Criterion
+}
+
+func (u *Usecase) SetTenderCriteria(ctx context.Context, input SetTenderCriteriaInput) ([]model.Criterion, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionEditTender) {
+		return nil, model.ErrNoRights
+	}
+	if tender.Status == model.TenderStatusClosed {
+		return nil, model.ErrCriteriaLocked.Wrap("tender_closed")
+	}
+	if err = validateCriteria(input.Criteria); err != nil {
+		return nil, err
+	}
+	return u.repo.SetTenderCriteria(ctx, repository.SetTenderCriteriaInput{
+		TenderID: input.TenderID,
+		Criteria: input.Criteria,
+	})
+}
+
+func validateCriteria(criteria []model.Criterion) error {
+	if len(criteria) == 0 || len(criteria) > _maxCriteria {
+		return model.ErrInvalidAttributeValue.Wrap("criteria_count")
+	}
+	names := make(map[string]struct{}, len(criteria))
+	for _, c := range criteria {
+		name := strings.ToLower(c.Name)
+		if _, ok := names[name]; ok {
+			return model.ErrInvalidAttributeValue.Wrap("criterion_names_repeat")
+		}
+		names[name] = struct{}{}
+		if c.Weight < 1 || c.Weight > _maxCriterionWeight {
+			return model.ErrInvalidAttributeValue.Wrap("criterion_weight")
+		}
+	}
+	return nil
+}
+
+type SubmitScoresInput struct {
+	BidID  string
+	Scores []model.BidScore
+}
+
+// SubmitScores records the caller's scores for the bid. Each scorer has one
+// score per criterion; submitting again overwrites it.
+func (u *Usecase) SubmitScores(ctx context.Context, input SubmitScoresInput) ([]model.BidScore, error) {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.IsAPIKey() {
+		return nil, model.ErrNoRights.Wrap("employees_score")
+	}
+	if !u.repo.BidExists(ctx, input.BidID) {
+		return nil, model.ErrNoBidFound
+	}
+	if !u.canOnBid(ctx, input.BidID, policy.ActionScoreBid) {
+		return nil, model.ErrNoRights
+	}
+	if err = validateScores(input.Scores); err != nil {
+		return nil, err
+	}
+	status, err := u.repo.GetBidStatus(ctx, input.BidID)
+	if err != nil {
+		return nil, err
+	}
+	if status != model.BidStatusPublished {
+		return nil, model.ErrNoRights.Wrap("published_bids_scored")
+	}
+	sealed, err := u.isBidSealed(ctx, input.BidID)
+	if err != nil {
+		return nil, err
+	}
+	if sealed {
+		return nil, model.ErrBidsSealed
+	}
+	return u.repo.SetBidScores(ctx, repository.SetBidScoresInput{
+		BidID:    input.BidID,
+		AuthorID: p.UserID,
+		Scores:   input.Scores,
+	})
+}
+
+func validateScores(scores []model.BidScore) error {
+	if len(scores) == 0 {
+		return model.ErrInvalidAttributeValue.Wrap("scores_required")
+	}
+	seen := make(map[string]struct{}, len(scores))
+	for _, s := range scores {
+		if _, ok := seen[s.CriterionID]; ok {
+			return model.ErrInvalidAttributeValue.Wrap("criterion_scored_twice")
+		}
+		seen[s.CriterionID] = struct{}{}
+		if s.Score < 0 || s.Score > _maxScore {
+			return model.ErrInvalidAttributeValue.Wrap("score_range")
+		}
+	}
+	return nil
+}
+
+type GetTenderRankingInput struct {
+	TenderID string
+	LotID    string
+	Limit    int
+	Offset   int
+}
+
+// GetTenderRanking orders the bids the caller can see by the weighted average
+// of their criterion scores. A criterion nobody has scored yet counts as zero.
+// Canceled bids are not ranked.
+func (u *Usecase) GetTenderRanking(ctx context.Context, input GetTenderRankingInput) ([]model.BidRanking, error) {
+	if input.Limit < 0 {
+		return nil, validate.Field(model.ErrInvalidQueryParam, "limit", "non_negative")
+	}
+	if input.Offset < 0 {
+		return nil, validate.Field(model.ErrInvalidQueryParam, "offset", "non_negative")
+	}
+	tenderBids, err := u.GetTenderBids(ctx, repository.GetTenderBidsInput{
+		TenderID:   input.TenderID,
+		LotID:      input.LotID,
+		Pagination: repository.Pagination{Limit: _rankingBidsLimit},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tenderBids.Sealed {
+		return nil, model.ErrBidsSealed
+	}
+	criteria, err := u.repo.GetTenderCriteria(ctx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(criteria) == 0 {
+		return nil, model.ErrNoCriteria
+	}
+	scores, err := u.repo.GetTenderScores(ctx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+
+	byBid := make(map[string]map[string]model.CriterionScore)
+	for _, s := range scores {
+		if byBid[s.BidID] == nil {
+			byBid[s.BidID] = make(map[string]model.CriterionScore)
+		}
+		byBid[s.BidID][s.CriterionID] = s
+	}
+	var totalWeight int
+	for _, c := range criteria {
+		totalWeight += c.Weight
+	}
+
+	ranking := make([]model.BidRanking, 0, len(tenderBids.Items))
+	for _, bid := range tenderBids.Items {
+		if bid.Status == model.BidStatusCanceled {
+			continue
+		}
+		entry := model.BidRanking{
+			Bid:    bid,
+			Scores: make([]model.CriterionScore, 0, len(criteria)),
+		}
+		var weighted float64
+		for _, c := range criteria {
+			s, ok := byBid[bid.ID][c.ID]
+			if !ok {
+				s = model.CriterionScore{BidID: bid.ID, CriterionID: c.ID}
+			}
+			weighted += float64(c.Weight) * s.Average
+			entry.Scores = append(entry.Scores, s)
+		}
+		entry.Total = math.Round(weighted/float64(totalWeight)*_scorePrecision) / _scorePrecision
+		ranking = append(ranking, entry)
+	}
+
+	// bids come sorted by name, which breaks ties between equal totals
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Total > ranking[j].Total
+	})
+	for i := range ranking {
+		if i > 0 && ranking[i].Total == ranking[i-1].Total {
+			ranking[i].Rank = ranking[i-1].Rank
+		} else {
+			ranking[i].Rank = i + 1
+		}
+	}
+
+	if input.Offset >= len(ranking) {
+		return []model.BidRanking{}, nil
+	}
+	ranking = ranking[input.Offset:]
+	if input.Limit < len(ranking) {
+		ranking = ranking[:input.Limit]
+	}
+	return ranking, nil
+}
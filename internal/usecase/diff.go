@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/diff"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type GetTenderDiffInput struct {
+	TenderID string
+	From     int
+	To       int
+}
+
+func (u *Usecase) GetTenderDiff(ctx context.Context, input GetTenderDiffInput) (model.VersionDiff, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.VersionDiff{}, err
+	}
+	if !u.repo.TenderExists(ctx, input.TenderID) {
+		return model.VersionDiff{}, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewTender) {
+		return model.VersionDiff{}, model.ErrNoRights
+	}
+	from, err := u.repo.GetTenderVersion(ctx, repository.GetTenderVersionInput{
+		TenderID: input.TenderID,
+		Version:  input.From,
+	})
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	to, err := u.repo.GetTenderVersion(ctx, repository.GetTenderVersionInput{
+		TenderID: input.TenderID,
+		Version:  input.To,
+	})
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	changes := make([]model.FieldDiff, 0)
+	changes = appendFieldDiff(changes, "name", from.Name, to.Name)
+	changes = appendDescriptionDiff(changes, from.Version, to.Version, from.Description, to.Description)
+	changes = appendFieldDiff(changes, "serviceType", from.ServiceType, to.ServiceType)
+	changes = appendFieldDiff(changes, "submissionDeadline", formatTime(from.SubmissionDeadline), formatTime(to.SubmissionDeadline))
+	changes = appendFieldDiff(changes, "budget", formatPrice(from.Budget, from.BudgetCurrency), formatPrice(to.Budget, to.BudgetCurrency))
+	changes = appendFieldDiff(changes, "enforceBudget", strconv.FormatBool(from.EnforceBudget), strconv.FormatBool(to.EnforceBudget))
+	return model.VersionDiff{
+		From:    input.From,
+		To:      input.To,
+		Changes: changes,
+	}, nil
+}
+
+type GetBidDiffInput struct {
+	BidID string
+	From  int
+	To    int
+}
+
+func (u *Usecase) GetBidDiff(ctx context.Context, input GetBidDiffInput) (model.VersionDiff, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.VersionDiff{}, err
+	}
+	if !u.repo.BidExists(ctx, input.BidID) {
+		return model.VersionDiff{}, model.ErrNoBidFound
+	}
+	hasAccess, err := u.isBidVisible(ctx, input.BidID)
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	if !hasAccess {
+		return model.VersionDiff{}, model.ErrNoRights
+	}
+	from, err := u.repo.GetBidVersion(ctx, repository.GetBidVersionInput{
+		BidID:   input.BidID,
+		Version: input.From,
+	})
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	to, err := u.repo.GetBidVersion(ctx, repository.GetBidVersionInput{
+		BidID:   input.BidID,
+		Version: input.To,
+	})
+	if err != nil {
+		return model.VersionDiff{}, err
+	}
+	changes := make([]model.FieldDiff, 0)
+	changes = appendFieldDiff(changes, "name", from.Name, to.Name)
+	changes = appendDescriptionDiff(changes, from.Version, to.Version, from.Description, to.Description)
+	changes = appendFieldDiff(changes, "price", formatPrice(from.Price, from.Currency), formatPrice(to.Price, to.Currency))
+	return model.VersionDiff{
+		From:    input.From,
+		To:      input.To,
+		Changes: changes,
+	}, nil
+}
+
+func appendFieldDiff(changes []model.FieldDiff, field, from, to string) []model.FieldDiff {
+	if from == to {
+		return changes
+	}
+	return append(changes, model.FieldDiff{
+		Field: field,
+		From:  from,
+		To:    to,
+	})
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func formatPrice(amount *model.Amount, currency *string) string {
+	if amount == nil || currency == nil {
+		return ""
+	}
+	return string(*amount) + " " + *currency
+}
+
+// appendDescriptionDiff also attaches a unified diff, since descriptions are too long to compare by eye.
+func appendDescriptionDiff(changes []model.FieldDiff, fromVersion, toVersion int, from, to string) []model.FieldDiff {
+	if from == to {
+		return changes
+	}
+	return append(changes, model.FieldDiff{
+		Field: "description",
+		From:  from,
+		To:    to,
+		Unified: diff.Unified(
+			"version "+strconv.Itoa(fromVersion),
+			"version "+strconv.Itoa(toVersion),
+			from, to,
+		),
+	})
+}
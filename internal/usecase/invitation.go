@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type GetTenderInvitationsInput struct {
+	TenderID string
+}
+
+func (u *Usecase) GetTenderInvitations(ctx context.Context, input GetTenderInvitationsInput) ([]model.Invitation, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	if !u.repo.TenderExists(ctx, input.TenderID) {
+		return nil, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewTender) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetTenderInvitations(ctx, input.TenderID)
+}
+
+type CreateInvitationInput struct {
+	TenderID       string `json:"-"`
+	OrganizationID string `json:"organizationId" validate:"uuid"`
+	Username       string `json:"username" validate:"max=50"`
+}
+
+// CreateInvitation admits an organization, with all its employees, or a single
+// employee to a private tender.
+func (u *Usecase) CreateInvitation(ctx context.Context, input CreateInvitationInput) (model.Invitation, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Invitation{}, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return model.Invitation{}, err
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionEditTender) {
+		return model.Invitation{}, model.ErrNoRights
+	}
+	if !tender.Private {
+		return model.Invitation{}, model.ErrInvalidAttributeValue.Wrap("invitations_private_only")
+	}
+	if (input.OrganizationID == "") == (input.Username == "") {
+		return model.Invitation{}, model.ErrInvalidAttributeValue.Wrap("invitee_required")
+	}
+	var userID string
+	if input.Username != "" {
+		if userID, err = u.repo.GetUserIDByUsername(ctx, input.Username); err != nil {
+			return model.Invitation{}, model.ErrInvalidAttributeValue.Wrap("employee_not_found")
+		}
+	}
+	return u.repo.CreateInvitation(ctx, repository.CreateInvitationInput{
+		TenderID:       input.TenderID,
+		OrganizationID: input.OrganizationID,
+		UserID:         userID,
+	})
+}
+
+type RemoveInvitationInput struct {
+	TenderID     string
+	InvitationID string
+}
+
+func (u *Usecase) RemoveInvitation(ctx context.Context, input RemoveInvitationInput) error {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return err
+	}
+	if !u.repo.TenderExists(ctx, input.TenderID) {
+		return model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionEditTender) {
+		return model.ErrNoRights
+	}
+	return u.repo.RemoveInvitation(ctx, repository.RemoveInvitationInput{
+		TenderID:     input.TenderID,
+		InvitationID: input.InvitationID,
+	})
+}
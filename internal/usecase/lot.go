@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type GetTenderLotsInput struct {
+	TenderID string
+}
+
+func (u *Usecase) GetTenderLots(ctx context.Context, input GetTenderLotsInput) ([]model.Lot, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetTenderLots(ctx, input.TenderID)
+}
+
+type CreateLotInput struct {
+	TenderID    string `json:"-"`
+	Name        string `json:"name" validate:"required,max=100"`
+	Description string `json:"description" validate:"required,max=500"`
+	ServiceType string `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
+}
+
+// CreateLot adds a lot to a tender that is not published yet, so that every
+// bid of a tender with lots is made for one of them.
+func (u *Usecase) CreateLot(ctx context.Context, input CreateLotInput) (model.Lot, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Lot{}, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionEditTender) {
+		return model.Lot{}, model.ErrNoRights
+	}
+	if tender.Status != model.TenderStatusCreated {
+		return model.Lot{}, model.ErrIllegalTransition.Wrap("lots_before_publishing")
+	}
+	return u.repo.CreateLot(ctx, repository.CreateLotInput{
+		TenderID:    input.TenderID,
+		Name:        input.Name,
+		Description: input.Description,
+		ServiceType: input.ServiceType,
+	})
+}
+
+type UpdateLotInput struct {
+	TenderID    string                 `json:"-"`
+	LotID       string                 `json:"-"`
+	Name        model.Optional[string] `json:"name" validate:"max=100"`
+	Description model.Optional[string] `json:"description" validate:"max=500"`
+	ServiceType model.Optional[string] `json:"serviceType" validate:"oneof=Construction Delivery Manufacture"`
+}
+
+func (u *Usecase) UpdateLot(ctx context.Context, input UpdateLotInput) (model.Lot, error) {
+	if _, err := u.editableLot(ctx, input.TenderID, input.LotID); err != nil {
+		return model.Lot{}, err
+	}
+	for _, field := range []model.Optional[string]{input.Name, input.Description, input.ServiceType} {
+		if err := checkRequired(field, "lot_fields_required"); err != nil {
+			return model.Lot{}, err
+		}
+	}
+	return u.repo.UpdateLot(ctx, repository.EditLotInput{
+		LotID:       input.LotID,
+		Name:        input.Name,
+		Description: input.Description,
+		ServiceType: input.ServiceType,
+	})
+}
+
+type RollbackLotInput struct {
+	TenderID string
+	LotID    string
+	Version  int
+}
+
+func (u *Usecase) RollbackLot(ctx context.Context, input RollbackLotInput) (model.Lot, error) {
+	if input.Version < 1 {
+		return model.Lot{}, model.ErrNoSuchVersion
+	}
+	if _, err := u.editableLot(ctx, input.TenderID, input.LotID); err != nil {
+		return model.Lot{}, err
+	}
+	return u.repo.RollbackLot(ctx, repository.RollbackLotInput{
+		LotID:   input.LotID,
+		Version: input.Version,
+	})
+}
+
+type GetLotVersionsInput struct {
+	TenderID string
+	LotID    string
+}
+
+func (u *Usecase) GetLotVersions(ctx context.Context, input GetLotVersionsInput) ([]model.LotVersion, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	if _, err := u.tenderLot(ctx, input.TenderID, input.LotID); err != nil {
+		return nil, err
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewTender) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetLotVersions(ctx, input.LotID)
+}
+
+// tenderLot returns the lot if it belongs to the tender.
+func (u *Usecase) tenderLot(ctx context.Context, tenderID, lotID string) (model.Lot, error) {
+	lot, err := u.repo.GetLotByID(ctx, lotID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+	if lot.TenderID != tenderID {
+		return model.Lot{}, model.ErrLotNotFound
+	}
+	return lot, nil
+}
+
+// editableLot checks that the caller may edit the lot and that it is still open.
+func (u *Usecase) editableLot(ctx context.Context, tenderID, lotID string) (model.Lot, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Lot{}, err
+	}
+	lot, err := u.tenderLot(ctx, tenderID, lotID)
+	if err != nil {
+		return model.Lot{}, err
+	}
+	if !u.canOnTender(ctx, tenderID, policy.ActionEditTender) {
+		return model.Lot{}, model.ErrNoRights
+	}
+	if lot.Status != model.LotStatusOpen {
+		return model.Lot{}, model.ErrIllegalTransition.Wrap("lot_awarded")
+	}
+	return lot, nil
+}
+
+// checkBidLot requires a bid to target an open lot of its tender if the tender
+// is split into lots, and no lot otherwise.
+func (u *Usecase) checkBidLot(ctx context.Context, tenderID string, lotID *string) error {
+	lots, err := u.repo.GetTenderLots(ctx, tenderID)
+	if err != nil {
+		return err
+	}
+	if len(lots) == 0 {
+		if lotID != nil {
+			return model.ErrInvalidAttributeValue.Wrap("tender_without_lots")
+		}
+		return nil
+	}
+	if lotID == nil {
+		return model.ErrInvalidAttributeValue.Wrap("lot_required")
+	}
+	for _, lot := range lots {
+		if lot.ID != *lotID {
+			continue
+		}
+		if lot.Status != model.LotStatusOpen {
+			return model.ErrSubmissionClosed.Wrap("lot_awarded")
+		}
+		return nil
+	}
+	return model.ErrInvalidAttributeValue.Wrap("lot_not_in_tender")
+}
@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type GetMembersInput struct {
+	OrganizationID string
+}
+
+func (u *Usecase) GetMembers(ctx context.Context, input GetMembersInput) ([]model.OrganizationMember, error) {
+	if err := u.checkMemberManager(ctx, input.OrganizationID); err != nil {
+		return nil, err
+	}
+	return u.repo.GetOrganizationMembers(ctx, input.OrganizationID)
+}
+
+type GrantRoleInput struct {
+	OrganizationID string
+	Username       string
+	Role           string
+}
+
+func (u *Usecase) GrantRole(ctx context.Context, input GrantRoleInput) (model.OrganizationMember, error) {
+	if !policy.IsRole(input.Role) {
+		return model.OrganizationMember{}, model.ErrInvalidRole
+	}
+	if err := u.checkMemberManager(ctx, input.OrganizationID); err != nil {
+		return model.OrganizationMember{}, err
+	}
+	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+	if err != nil {
+		return model.OrganizationMember{}, model.ErrMemberNotFound.Wrap("employee_not_found")
+	}
+	if input.Role != policy.RoleAdmin {
+		if err = u.checkNotLastAdmin(ctx, input.OrganizationID, userID); err != nil {
+			return model.OrganizationMember{}, err
+		}
+	}
+	return u.repo.SetMemberRole(ctx, repository.SetMemberRoleInput{
+		OrganizationID: input.OrganizationID,
+		UserID:         userID,
+		Role:           input.Role,
+	})
+}
+
+type RevokeMemberInput struct {
+	OrganizationID string
+	Username       string
+}
+
+func (u *Usecase) RevokeMember(ctx context.Context, input RevokeMemberInput) error {
+	if err := u.checkMemberManager(ctx, input.OrganizationID); err != nil {
+		return err
+	}
+	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+	if err != nil {
+		return model.ErrMemberNotFound
+	}
+	if err = u.checkNotLastAdmin(ctx, input.OrganizationID, userID); err != nil {
+		return err
+	}
+	return u.repo.RemoveMember(ctx, repository.RemoveMemberInput{
+		OrganizationID: input.OrganizationID,
+		UserID:         userID,
+	})
+}
+
+// checkMemberManager checks that the caller is an employee allowed to manage
+// memberships of the organization. API keys cannot change roles.
+func (u *Usecase) checkMemberManager(ctx context.Context, orgID string) error {
+	p, err := currentPrincipal(ctx)
+	if err != nil {
+		return err
+	}
+	if p.IsAPIKey() {
+		return model.ErrNoRights.Wrap("api_key_manages_roles")
+	}
+	if !u.can(ctx, orgID, policy.ActionManageMembers) {
+		return model.ErrNoRights
+	}
+	return nil
+}
+
+// checkNotLastAdmin prevents an organization from losing its last administrator.
+func (u *Usecase) checkNotLastAdmin(ctx context.Context, orgID, userID string) error {
+	role, err := u.repo.GetMemberRole(ctx, orgID, userID)
+	if err != nil || role != policy.RoleAdmin {
+		return nil
+	}
+	admins, err := u.repo.CountMembersWithRole(ctx, repository.CountMembersWithRoleInput{
+		OrganizationID: orgID,
+		Role:           policy.RoleAdmin,
+	})
+	if err != nil {
+		return err
+	}
+	if admins <= 1 {
+		return model.ErrNoRights.Wrap("last_admin")
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/money"
+)
+
+// checkRequired rejects clearing a required text field in a partial update,
+// explaining it with the catalog text key.
+func checkRequired(field model.Optional[string], key string) error {
+	if field.Set && (field.Null || field.Value == "") {
+		return model.ErrInvalidAttributeValue.Wrap(key)
+	}
+	return nil
+}
+
+// checkPriceUpdate checks an amount and a currency, which are changed together:
+// both set to valid values, both cleared or both omitted.
+func checkPriceUpdate(amount model.Optional[model.Amount], currency model.Optional[string]) error {
+	if amount.Set != currency.Set {
+		return model.ErrInvalidAttributeValue.Wrap("price_pair")
+	}
+	return money.ValidatePrice(amount.Ptr(), currency.Ptr())
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+// can reports whether the caller's role in the organization permits the action.
+// API keys act with policy.APIKeyRole within their own organization only.
+func (u *Usecase) can(ctx context.Context, orgID string, action policy.Action) bool {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return false
+	}
+	if p.IsAPIKey() {
+		return p.OrganizationID == orgID && policy.Allows(policy.APIKeyRole, action)
+	}
+	role, err := u.repo.GetMemberRole(ctx, orgID, p.UserID)
+	if err != nil {
+		return false
+	}
+	return policy.Allows(role, action)
+}
+
+// canOnTender checks the action against the organization the tender belongs to.
+func (u *Usecase) canOnTender(ctx context.Context, tenderID string, action policy.Action) bool {
+	orgID, err := u.repo.GetTenderOrganizationID(ctx, tenderID)
+	if err != nil {
+		return false
+	}
+	return u.can(ctx, orgID, action)
+}
+
+// canOnBid checks the action against the organization of the bid's tender.
+func (u *Usecase) canOnBid(ctx context.Context, bidID string, action policy.Action) bool {
+	orgID, err := u.repo.GetBidTenderOrganizationID(ctx, bidID)
+	if err != nil {
+		return false
+	}
+	return u.can(ctx, orgID, action)
+}
+
+// canSeeTender reports whether the caller may see details of the tender:
+// published and closed tenders are public unless private, which are shown to
+// invitees only, and drafts are shown only to the organization.
+func (u *Usecase) canSeeTender(ctx context.Context, tender model.Tender) bool {
+	if tender.Status == model.TenderStatusCreated {
+		return u.canOnTender(ctx, tender.ID, policy.ActionViewTender)
+	}
+	if !tender.Private {
+		return true
+	}
+	p, _ := auth.PrincipalFromContext(ctx)
+	admitted, err := u.repo.TenderAdmits(ctx, repository.TenderAdmitsInput{
+		TenderID:       tender.ID,
+		UserID:         p.UserID,
+		OrganizationID: p.OrganizationID,
+	})
+	return err == nil && admitted
+}
+
+// isBidVisible reports whether the caller is the bid author or may view bids
+// of its tender, which are not sealed.
+func (u *Usecase) isBidVisible(ctx context.Context, bidID string) (bool, error) {
+	p, ok := auth.PrincipalFromContext(ctx)
+	if !ok {
+		return false, nil
+	}
+	if !p.IsAPIKey() {
+		authorID, err := u.repo.GetUserIDByBidID(ctx, bidID)
+		if err != nil {
+			return false, err
+		}
+		if authorID == p.UserID {
+			return true, nil
+		}
+	}
+	if !u.canOnBid(ctx, bidID, policy.ActionViewBids) {
+		return false, nil
+	}
+	sealed, err := u.isBidSealed(ctx, bidID)
+	if err != nil {
+		return false, err
+	}
+	return !sealed, nil
+}
+
+// isBidSealed reports whether the bid contents are hidden from the tender organization yet.
+func (u *Usecase) isBidSealed(ctx context.Context, bidID string) (bool, error) {
+	tenderID, err := u.repo.GetBidTenderID(ctx, bidID)
+	if err != nil {
+		return false, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, tenderID)
+	if err != nil {
+		return false, err
+	}
+	return tender.BidsSealed(time.Now()), nil
+}
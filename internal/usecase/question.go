@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type AskQuestionInput struct {
+	TenderID string `json:"-"`
+	Question string `json:"question" validate:"required,max=1000"`
+}
+
+// AskQuestion lets any user ask about a published tender.
+func (u *Usecase) AskQuestion(ctx context.Context, input AskQuestionInput) (model.Question, error) {
+	userID, err := currentUserID(ctx)
+	if err != nil {
+		return model.Question{}, err
+	}
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return model.Question{}, err
+	}
+	if tender.Status != model.TenderStatusPublished {
+		return model.Question{}, model.ErrNoRights.Wrap("tender_not_published")
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return model.Question{}, model.ErrNoRights.Wrap("tender_invite_only")
+	}
+	return u.repo.CreateQuestion(ctx, repository.CreateQuestionInput{
+		TenderID: input.TenderID,
+		AuthorID: userID,
+		Question: input.Question,
+	})
+}
+
+type GetTenderQuestionsInput struct {
+	TenderID string
+	Limit    int
+	Offset   int
+}
+
+// GetTenderQuestions returns every question to the tender organization. Other
+// users see questions of published tenders visible to them, as in GetTenders,
+// and only public ones and their own; anonymous callers see public ones only.
+func (u *Usecase) GetTenderQuestions(ctx context.Context, input GetTenderQuestionsInput) ([]model.Question, error) {
+	p, _ := auth.PrincipalFromContext(ctx)
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return nil, err
+	}
+	all := u.canOnTender(ctx, input.TenderID, policy.ActionViewTender)
+	if !all && (tender.Status != model.TenderStatusPublished || !u.canSeeTender(ctx, tender)) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetTenderQuestions(ctx, repository.GetTenderQuestionsInput{
+		TenderID: input.TenderID,
+		ViewerID: p.UserID,
+		All:      all,
+		Limit:    input.Limit,
+		Offset:   input.Offset,
+	})
+}
+
+type AnswerQuestionInput struct {
+	TenderID   string `json:"-"`
+	QuestionID string `json:"-"`
+	Answer     string `json:"answer" validate:"required,max=1000"`
+	Public     bool   `json:"public"`
+}
+
+func (u *Usecase) AnswerQuestion(ctx context.Context, input AnswerQuestionInput) (model.Question, error) {
+	userID, err := currentAuthorID(ctx)
+	if err != nil {
+		return model.Question{}, err
+	}
+	if !u.repo.TenderExists(ctx, input.TenderID) {
+		return model.Question{}, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionAnswerTender) {
+		return model.Question{}, model.ErrNoRights
+	}
+	return u.repo.AnswerQuestion(ctx, repository.AnswerQuestionInput{
+		TenderID:   input.TenderID,
+		QuestionID: input.QuestionID,
+		AnswererID: userID,
+		Answer:     input.Answer,
+		Public:     input.Public,
+	})
+}
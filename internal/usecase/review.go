@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+	"github.com/b0pof/avito-internship/internal/repository"
+)
+
+type SubmitFeedbackInput struct {
+	BidID    string
+	Feedback string
+}
+
+func (u *Usecase) SubmitFeedback(ctx context.Context, input SubmitFeedbackInput) (model.Bid, error) {
+	userID, err := currentAuthorID(ctx)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if !u.repo.BidExists(ctx, input.BidID) {
+		return model.Bid{}, model.ErrNoBidFound
+	}
+	if !u.canOnBid(ctx, input.BidID, policy.ActionReviewBid) {
+		return model.Bid{}, model.ErrNoRights
+	}
+	sealed, err := u.isBidSealed(ctx, input.BidID)
+	if err != nil {
+		return model.Bid{}, err
+	}
+	if sealed {
+		return model.Bid{}, model.ErrBidsSealed
+	}
+	err = u.repo.CreateBidReview(ctx, repository.CreateBidReviewInput{
+		BidID:       input.BidID,
+		AuthorID:    userID,
+		Description: input.Feedback,
+	})
+	if err != nil {
+		return model.Bid{}, err
+	}
+	return u.repo.GetBidByID(ctx, input.BidID)
+}
+
+type GetBidReviewsInput struct {
+	TenderID       string
+	AuthorUsername string
+	Limit          int
+	Offset         int
+}
+
+func (u *Usecase) GetBidReviews(ctx context.Context, input GetBidReviewsInput) ([]model.BidReview, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	if !u.repo.TenderExists(ctx, input.TenderID) {
+		return nil, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewBids) {
+		return nil, model.ErrNoRights
+	}
+	authorID, err := u.repo.GetUserIDByUsername(ctx, input.AuthorUsername)
+	if err != nil {
+		return nil, err
+	}
+	if !u.repo.HasUserBidOnTender(ctx, input.TenderID, authorID) {
+		return nil, model.ErrNoBidsFound.Wrap("author_has_no_bids")
+	}
+	return u.repo.GetAuthorReviews(ctx, repository.GetAuthorReviewsInput{
+		AuthorID: authorID,
+		Limit:    input.Limit,
+		Offset:   input.Offset,
+	})
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+)
+
+type transition struct {
+	From string
+	To   string
+}
+
+// _tenderTransitions lists allowed tender status changes and the action the
+// caller needs in the tender's organization to trigger each of them.
+var _tenderTransitions = map[transition]policy.Action{
+	{model.TenderStatusCreated, model.TenderStatusPublished}: policy.ActionPublishTender,
+	{model.TenderStatusCreated, model.TenderStatusClosed}:    policy.ActionPublishTender,
+	{model.TenderStatusPublished, model.TenderStatusClosed}:  policy.ActionPublishTender,
+}
+
+// bidTransitionRule tells who may trigger a bid status change: the bid author
+// and/or members of the tender's organization allowed to perform Action.
+type bidTransitionRule struct {
+	Author bool
+	Action policy.Action
+}
+
+var _bidTransitions = map[transition]bidTransitionRule{
+	{model.BidStatusCreated, model.BidStatusPublished}: {Author: true},
+	{model.BidStatusCreated, model.BidStatusCanceled}:  {Author: true},
+	{model.BidStatusPublished, model.BidStatusCanceled}: {
+		Author: true,
+		Action: policy.ActionDecideBid,
+	},
+}
+
+func isTenderStatus(status string) bool {
+	switch status {
+	case model.TenderStatusCreated, model.TenderStatusPublished, model.TenderStatusClosed:
+		return true
+	}
+	return false
+}
+
+func isBidStatus(status string) bool {
+	switch status {
+	case model.BidStatusCreated, model.BidStatusPublished, model.BidStatusCanceled:
+		return true
+	}
+	return false
+}
+
+func tenderTransition(from, to string) (policy.Action, error) {
+	if !isTenderStatus(to) {
+		return "", model.ErrInvalidAttributeValue
+	}
+	action, ok := _tenderTransitions[transition{from, to}]
+	if !ok {
+		return "", model.ErrIllegalTransition.Wrap("transition", from, to)
+	}
+	return action, nil
+}
+
+func bidTransition(from, to string) (bidTransitionRule, error) {
+	if !isBidStatus(to) {
+		return bidTransitionRule{}, model.ErrInvalidAttributeValue
+	}
+	rule, ok := _bidTransitions[transition{from, to}]
+	if !ok {
+		return bidTransitionRule{}, model.ErrIllegalTransition.Wrap("transition", from, to)
+	}
+	return rule, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/pkg/errors"
+
+	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
+)
+
+const _unknownStatus = "Archived"
+
+func TestTenderTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		action   policy.Action
+		err      error
+	}{
+		{model.TenderStatusCreated, model.TenderStatusCreated, "", model.ErrIllegalTransition},
+		{model.TenderStatusCreated, model.TenderStatusPublished, policy.ActionPublishTender, nil},
+		{model.TenderStatusCreated, model.TenderStatusClosed, policy.ActionPublishTender, nil},
+		{model.TenderStatusCreated, _unknownStatus, "", model.ErrInvalidAttributeValue},
+
+		{model.TenderStatusPublished, model.TenderStatusCreated, "", model.ErrIllegalTransition},
+		{model.TenderStatusPublished, model.TenderStatusPublished, "", model.ErrIllegalTransition},
+		{model.TenderStatusPublished, model.TenderStatusClosed, policy.ActionPublishTender, nil},
+		{model.TenderStatusPublished, _unknownStatus, "", model.ErrInvalidAttributeValue},
+
+		{model.TenderStatusClosed, model.TenderStatusCreated, "", model.ErrIllegalTransition},
+		{model.TenderStatusClosed, model.TenderStatusPublished, "", model.ErrIllegalTransition},
+		{model.TenderStatusClosed, model.TenderStatusClosed, "", model.ErrIllegalTransition},
+		{model.TenderStatusClosed, _unknownStatus, "", model.ErrInvalidAttributeValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			action, err := tenderTransition(tt.from, tt.to)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if action != tt.action {
+				t.Errorf("action = %q, want %q", action, tt.action)
+			}
+		})
+	}
+}
+
+func TestBidTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		rule     bidTransitionRule
+		err      error
+	}{
+		{model.BidStatusCreated, model.BidStatusCreated, bidTransitionRule{}, model.ErrIllegalTransition},
+		{model.BidStatusCreated, model.BidStatusPublished, bidTransitionRule{Author: true}, nil},
+		{model.BidStatusCreated, model.BidStatusCanceled, bidTransitionRule{Author: true}, nil},
+		{model.BidStatusCreated, _unknownStatus, bidTransitionRule{}, model.ErrInvalidAttributeValue},
+
+		{model.BidStatusPublished, model.BidStatusCreated, bidTransitionRule{}, model.ErrIllegalTransition},
+		{model.BidStatusPublished, model.BidStatusPublished, bidTransitionRule{}, model.ErrIllegalTransition},
+		{model.BidStatusPublished, model.BidStatusCanceled, bidTransitionRule{Author: true, Action: policy.ActionDecideBid}, nil},
+		{model.BidStatusPublished, _unknownStatus, bidTransitionRule{}, model.ErrInvalidAttributeValue},
+
+		{model.BidStatusCanceled, model.BidStatusCreated, bidTransitionRule{}, model.ErrIllegalTransition},
+		{model.BidStatusCanceled, model.BidStatusPublished, bidTransitionRule{}, model.ErrIllegalTransition},
+		{model.BidStatusCanceled, model.BidStatusCanceled, bidTransitionRule{}, model.ErrIllegalTransition},
+		{model.BidStatusCanceled, _unknownStatus, bidTransitionRule{}, model.ErrInvalidAttributeValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.from+"->"+tt.to, func(t *testing.T) {
+			rule, err := bidTransition(tt.from, tt.to)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("error = %v, want %v", err, tt.err)
+			}
+			if rule != tt.rule {
+				t.Errorf("rule = %+v, want %+v", rule, tt.rule)
+			}
+		})
+	}
+}
+
+// TestTransitionsCoverStatuses fails once a transition uses a status the
+// tables above do not enumerate, so that a new status gets its pairs tested.
+func TestTransitionsCoverStatuses(t *testing.T) {
+	tenderStatuses := []string{model.TenderStatusCreated, model.TenderStatusPublished, model.TenderStatusClosed}
+	bidStatuses := []string{model.BidStatusCreated, model.BidStatusPublished, model.BidStatusCanceled}
+	for tr := range _tenderTransitions {
+		if !slices.Contains(tenderStatuses, tr.From) || !slices.Contains(tenderStatuses, tr.To) {
+			t.Errorf("tender transition %s -> %s uses a status missing from the test", tr.From, tr.To)
+		}
+	}
+	for tr := range _bidTransitions {
+		if !slices.Contains(bidStatuses, tr.From) || !slices.Contains(bidStatuses, tr.To) {
+			t.Errorf("bid transition %s -> %s uses a status missing from the test", tr.From, tr.To)
+		}
+	}
+}
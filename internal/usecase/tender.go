@@ -2,137 +2,210 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/b0pof/avito-internship/internal/model"
+	"github.com/b0pof/avito-internship/internal/pkg/auth"
+	"github.com/b0pof/avito-internship/internal/pkg/money"
+	"github.com/b0pof/avito-internship/internal/pkg/policy"
 	"github.com/b0pof/avito-internship/internal/repository"
 )
 
-func (u *Usecase) GetTenders(ctx context.Context, input repository.GetTendersInput) ([]model.Tender, error) {
+func (u *Usecase) GetTenders(ctx context.Context, input repository.GetTendersInput) (model.Page[model.Tender], error) {
+	p, _ := auth.PrincipalFromContext(ctx)
+	input.ViewerID = p.UserID
+	input.ViewerOrganizationID = p.OrganizationID
 	return u.repo.GetTenders(ctx, input)
 }
 
+type GetTenderInput struct {
+	TenderID string
+}
+
+func (u *Usecase) GetTender(ctx context.Context, input GetTenderInput) (model.Tender, error) {
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return model.Tender{}, err
+	}
+	if !u.canSeeTender(ctx, tender) {
+		return model.Tender{}, model.ErrNoRights
+	}
+	return tender, nil
+}
+
 type CreateTenderInput struct {
-	Name            string `json:"name"`
-	Description     string `json:"description"`
-	ServiceType     string `json:"serviceType"`
-	OrganizationID  string `json:"organizationId"`
-	CreatorUsername string `json:"creatorUsername"`
+	Name               string        `json:"name" validate:"required,max=100"`
+	Description        string        `json:"description" validate:"required,max=500"`
+	ServiceType        string        `json:"serviceType" validate:"required,oneof=Construction Delivery Manufacture"`
+	SubmissionDeadline *time.Time    `json:"submissionDeadline"`
+	Budget             *model.Amount `json:"budget"`
+	BudgetCurrency     *string       `json:"budgetCurrency"`
+	EnforceBudget      bool          `json:"enforceBudget"`
+	Sealed             bool          `json:"sealed"`
+	Private            bool          `json:"private"`
+	Auction            bool          `json:"auction"`
+	AuctionStart       *time.Time    `json:"auctionStart"`
+	AuctionStep        *model.Amount `json:"auctionStep"`
+	AuctionExtension   int           `json:"auctionExtension"`
+	OrganizationID     string        `json:"organizationId" validate:"required,uuid"`
+	// CreatorUsername is accepted as in the API contract, but the creator is
+	// always the authenticated caller.
+	CreatorUsername string `json:"creatorUsername" validate:"max=50"`
 }
 
 func (u *Usecase) CreateTender(ctx context.Context, input CreateTenderInput) (model.Tender, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.CreatorUsername)
+	userID, err := currentAuthorID(ctx)
 	if err != nil {
 		return model.Tender{}, err
 	}
-	if !u.repo.IsUserOrganizationResponsible(ctx, userID, input.OrganizationID) {
+	if !u.can(ctx, input.OrganizationID, policy.ActionCreateTender) {
 		return model.Tender{}, model.ErrNoRights
 	}
+	if !isFutureDeadline(input.SubmissionDeadline) {
+		return model.Tender{}, model.ErrInvalidAttributeValue.Wrap("deadline_in_past")
+	}
+	if input.Sealed && input.SubmissionDeadline == nil {
+		return model.Tender{}, model.ErrInvalidAttributeValue.Wrap("sealed_deadline_required")
+	}
+	if err = money.ValidatePrice(input.Budget, input.BudgetCurrency); err != nil {
+		return model.Tender{}, err
+	}
+	if input.EnforceBudget && input.Budget == nil {
+		return model.Tender{}, model.ErrInvalidAttributeValue.Wrap("enforced_budget_required")
+	}
+	if err = validateAuction(input); err != nil {
+		return model.Tender{}, err
+	}
 	return u.repo.CreateTender(ctx, repository.CreateTenderInput{
-		Name:           input.Name,
-		Description:    input.Description,
-		ServiceType:    input.ServiceType,
-		OrganizationID: input.OrganizationID,
-		CreatorID:      userID,
+		Name:               input.Name,
+		Description:        input.Description,
+		ServiceType:        input.ServiceType,
+		SubmissionDeadline: input.SubmissionDeadline,
+		Budget:             input.Budget,
+		BudgetCurrency:     input.BudgetCurrency,
+		EnforceBudget:      input.EnforceBudget,
+		Sealed:             input.Sealed,
+		Private:            input.Private,
+		Auction:            input.Auction,
+		AuctionStart:       input.AuctionStart,
+		AuctionStep:        input.AuctionStep,
+		AuctionExtension:   input.AuctionExtension,
+		OrganizationID:     input.OrganizationID,
+		CreatorID:          userID,
 	})
 }
 
 type GetMyTendersInput struct {
-	Limit    int
-	Offset   int
-	Username string
+	repository.Pagination
 }
 
-func (u *Usecase) GetMyTenders(ctx context.Context, input GetMyTendersInput) ([]model.Tender, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+func (u *Usecase) GetMyTenders(ctx context.Context, input GetMyTendersInput) (model.Page[model.Tender], error) {
+	userID, err := currentUserID(ctx)
 	if err != nil {
-		return nil, err
+		return model.Page[model.Tender]{}, err
 	}
 	return u.repo.GetMyTenders(ctx, repository.GetMyTendersInput{
-		Limit:  input.Limit,
-		Offset: input.Offset,
-		UserID: userID,
+		UserID:     userID,
+		Pagination: input.Pagination,
 	})
 }
 
 type GetTenderStatusInput struct {
 	TenderID string
-	Username string
 }
 
-func (u *Usecase) GetTenderStatus(ctx context.Context, input GetTenderStatusInput) (string, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
-	if err != nil {
-		return "", err
+// GetTenderStatus returns the tender, whose status is reported along with
+// the version for the ETag.
+func (u *Usecase) GetTenderStatus(ctx context.Context, input GetTenderStatusInput) (model.Tender, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Tender{}, err
 	}
-	status, err := u.repo.GetTenderStatus(ctx, input.TenderID)
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
 	if err != nil {
-		return "", err
+		return model.Tender{}, err
 	}
-	if !u.repo.IsUserResponsibleForTender(ctx, input.TenderID, userID) {
-		return "", model.ErrNoRights
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewTender) {
+		return model.Tender{}, model.ErrNoRights
 	}
-	return status, nil
+	return tender, nil
 }
 
 type UpdateTenderStatusInput struct {
-	TenderID string `json:"tenderId"`
-	Status   string `json:"status"`
-	Username string `json:"username"`
+	TenderID         string `json:"tenderId"`
+	Status           string `json:"status"`
+	ExpectedVersions []int  `json:"-"`
 }
 
 func (u *Usecase) UpdateTenderStatus(ctx context.Context, input UpdateTenderStatusInput) (model.Tender, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
+	if _, err := currentPrincipal(ctx); err != nil {
+		return model.Tender{}, err
+	}
+	current, err := u.repo.GetTenderStatus(ctx, input.TenderID)
 	if err != nil {
 		return model.Tender{}, err
 	}
-	if !u.repo.TenderExists(ctx, input.TenderID) {
-		return model.Tender{}, model.ErrTenderNotFound
+	action, err := tenderTransition(current, input.Status)
+	if err != nil {
+		return model.Tender{}, err
 	}
-	if !u.repo.IsUserResponsibleForTender(ctx, input.TenderID, userID) {
+	if !u.canOnTender(ctx, input.TenderID, action) {
 		return model.Tender{}, model.ErrNoRights
 	}
 	return u.repo.UpdateTenderStatus(ctx, repository.UpdateTenderStatusInput{
-		Status:   input.Status,
-		TenderID: input.TenderID,
+		TenderID:         input.TenderID,
+		FromStatus:       current,
+		Status:           input.Status,
+		ExpectedVersions: input.ExpectedVersions,
 	})
 }
 
 type UpdateTenderInput struct {
-	TenderID    string
-	Username    string
-	Name        string
-	Description string
-	ServiceType string
+	TenderID           string
+	Name               model.Optional[string]
+	Description        model.Optional[string]
+	ServiceType        model.Optional[string]
+	SubmissionDeadline model.Optional[time.Time]
+	Budget             model.Optional[model.Amount]
+	BudgetCurrency     model.Optional[string]
+	EnforceBudget      model.Optional[bool]
+	ExpectedVersions   []int
 }
 
 func (u *Usecase) UpdateTender(ctx context.Context, input UpdateTenderInput) (model.Tender, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
-	if err != nil {
+	if _, err := currentPrincipal(ctx); err != nil {
 		return model.Tender{}, err
 	}
-	if !u.repo.TenderExists(ctx, input.TenderID) {
-		return model.Tender{}, model.ErrTenderNotFound
+	tender, err := u.repo.GetTenderByID(ctx, input.TenderID)
+	if err != nil {
+		return model.Tender{}, err
 	}
-	if !u.repo.IsUserResponsibleForTender(ctx, input.TenderID, userID) {
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionEditTender) {
 		return model.Tender{}, model.ErrNoRights
 	}
+	if err = validateTenderUpdate(tender, input); err != nil {
+		return model.Tender{}, err
+	}
 	return u.repo.UpdateTender(ctx, repository.EditTenderInput{
-		TenderID:    input.TenderID,
-		Name:        input.Name,
-		Description: input.Description,
-		ServiceType: input.ServiceType,
+		TenderID:           input.TenderID,
+		Name:               input.Name,
+		Description:        input.Description,
+		ServiceType:        input.ServiceType,
+		SubmissionDeadline: input.SubmissionDeadline,
+		Budget:             input.Budget,
+		BudgetCurrency:     input.BudgetCurrency,
+		EnforceBudget:      input.EnforceBudget,
+		ExpectedVersions:   input.ExpectedVersions,
 	})
 }
 
 type RollbackTenderInput struct {
-	TenderID string
-	Version  int
-	Username string
+	TenderID         string
+	Version          int
+	ExpectedVersions []int
 }
 
 func (u *Usecase) RollbackTender(ctx context.Context, input RollbackTenderInput) (model.Tender, error) {
-	userID, err := u.repo.GetUserIDByUsername(ctx, input.Username)
-	if err != nil {
+	if _, err := currentPrincipal(ctx); err != nil {
 		return model.Tender{}, err
 	}
 	if !u.repo.TenderExists(ctx, input.TenderID) {
@@ -148,11 +221,67 @@ func (u *Usecase) RollbackTender(ctx context.Context, input RollbackTenderInput)
 	if !hasVersion {
 		return model.Tender{}, model.ErrNoSuchVersion
 	}
-	if !u.repo.IsUserResponsibleForTender(ctx, input.TenderID, userID) {
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionEditTender) {
 		return model.Tender{}, model.ErrNoRights
 	}
 	return u.repo.RollbackTender(ctx, repository.RollbackTenderInput{
-		TenderID: input.TenderID,
-		Version:  input.Version,
+		TenderID:         input.TenderID,
+		Version:          input.Version,
+		ExpectedVersions: input.ExpectedVersions,
 	})
 }
+
+type GetTenderVersionsInput struct {
+	TenderID string
+}
+
+func (u *Usecase) GetTenderVersions(ctx context.Context, input GetTenderVersionsInput) ([]model.TenderVersion, error) {
+	if _, err := currentPrincipal(ctx); err != nil {
+		return nil, err
+	}
+	if !u.repo.TenderExists(ctx, input.TenderID) {
+		return nil, model.ErrTenderNotFound
+	}
+	if !u.canOnTender(ctx, input.TenderID, policy.ActionViewTender) {
+		return nil, model.ErrNoRights
+	}
+	return u.repo.GetTenderVersions(ctx, input.TenderID)
+}
+
+// CloseExpiredTenders closes published tenders whose submission deadline has passed.
+func (u *Usecase) CloseExpiredTenders(ctx context.Context) (int, error) {
+	return u.repo.CloseExpiredTenders(ctx)
+}
+
+// validateTenderUpdate checks the fields of a partial tender update. Omitted
+// fields are kept; required ones and the terms of sealed and auction tenders
+// cannot be cleared.
+func validateTenderUpdate(tender model.Tender, input UpdateTenderInput) error {
+	if err := checkRequired(input.Name, "tender_name_required"); err != nil {
+		return err
+	}
+	if input.Description.Cleared() || input.EnforceBudget.Cleared() {
+		return model.ErrInvalidAttributeValue.Wrap("field_not_null")
+	}
+	if err := checkRequired(input.ServiceType, "service_type_required"); err != nil {
+		return err
+	}
+	if !isFutureDeadline(input.SubmissionDeadline.Ptr()) {
+		return model.ErrInvalidAttributeValue.Wrap("deadline_in_past")
+	}
+	if input.SubmissionDeadline.Cleared() && (tender.Sealed || tender.Auction) {
+		return model.ErrInvalidAttributeValue.Wrap("sealed_deadline")
+	}
+	if err := checkPriceUpdate(input.Budget, input.BudgetCurrency); err != nil {
+		return err
+	}
+	if input.Budget.Cleared() && tender.Auction {
+		return model.ErrInvalidAttributeValue.Wrap("auction_start_price")
+	}
+	return nil
+}
+
+// isFutureDeadline reports whether the deadline is absent or not passed yet.
+func isFutureDeadline(deadline *time.Time) bool {
+	return deadline == nil || deadline.After(time.Now())
+}
@@ -8,37 +8,126 @@ import (
 )
 
 type Usecase struct {
-	repo repository.IRepository
+	repo   repository.IRepository
+	tokens TokenIssuer
+	blobs  BlobStorage
 }
 
-func New(repo repository.IRepository) *Usecase {
+func New(repo repository.IRepository, tokens TokenIssuer, blobs BlobStorage) *Usecase {
 	return &Usecase{
-		repo: repo,
+		repo:   repo,
+		tokens: tokens,
+		blobs:  blobs,
 	}
 }
 
 type IUsecase interface {
 	IBidUsecase
 	ITenderUsecase
+	IReviewUsecase
+	IAuthUsecase
+	IAPIKeyUsecase
+	IMemberUsecase
+	IAuditUsecase
+	ICriterionUsecase
+	ILotUsecase
+	IAttachmentUsecase
+	IQuestionUsecase
+	IInvitationUsecase
+	IAuctionUsecase
 }
 
 type IBidUsecase interface {
 	CreateBid(ctx context.Context, input repository.CreateBidInput) (model.Bid, error)
-	GetMyBids(ctx context.Context, input GetMyBidsInput) ([]model.Bid, error)
-	GetTenderBids(ctx context.Context, input repository.GetTenderBidsInput) ([]model.Bid, error)
-	GetBidStatus(ctx context.Context, input GetBidStatusInput) (string, error)
+	GetMyBids(ctx context.Context, input GetMyBidsInput) (model.Page[model.Bid], error)
+	GetTenderBids(ctx context.Context, input repository.GetTenderBidsInput) (model.TenderBids, error)
+	GetBidStatus(ctx context.Context, input GetBidStatusInput) (model.Bid, error)
 	UpdateBidStatus(ctx context.Context, input UpdateBidStatusInput) (model.Bid, error)
-	SubmitDecision(ctx context.Context, input SubmitDecisionInput) (model.Bid, error)
+	SubmitDecision(ctx context.Context, input SubmitDecisionInput) (model.BidDecisionResult, error)
 	UpdateBid(ctx context.Context, input UpdateBidInput) (model.Bid, error)
 	RollbackBid(ctx context.Context, input RollbackBidInput) (model.Bid, error)
+	GetBidVersions(ctx context.Context, input GetBidVersionsInput) ([]model.BidVersion, error)
+	GetBidDiff(ctx context.Context, input GetBidDiffInput) (model.VersionDiff, error)
 }
 
 type ITenderUsecase interface {
-	GetTenders(ctx context.Context, input repository.GetTendersInput) ([]model.Tender, error)
+	GetTenders(ctx context.Context, input repository.GetTendersInput) (model.Page[model.Tender], error)
+	GetTender(ctx context.Context, input GetTenderInput) (model.Tender, error)
 	CreateTender(ctx context.Context, input CreateTenderInput) (model.Tender, error)
-	GetMyTenders(ctx context.Context, input GetMyTendersInput) ([]model.Tender, error)
-	GetTenderStatus(ctx context.Context, input GetTenderStatusInput) (string, error)
+	GetMyTenders(ctx context.Context, input GetMyTendersInput) (model.Page[model.Tender], error)
+	GetTenderStatus(ctx context.Context, input GetTenderStatusInput) (model.Tender, error)
 	UpdateTenderStatus(ctx context.Context, input UpdateTenderStatusInput) (model.Tender, error)
 	UpdateTender(ctx context.Context, input UpdateTenderInput) (model.Tender, error)
 	RollbackTender(ctx context.Context, input RollbackTenderInput) (model.Tender, error)
+	GetTenderVersions(ctx context.Context, input GetTenderVersionsInput) ([]model.TenderVersion, error)
+	GetTenderDiff(ctx context.Context, input GetTenderDiffInput) (model.VersionDiff, error)
+	CloseExpiredTenders(ctx context.Context) (int, error)
+}
+
+type IReviewUsecase interface {
+	SubmitFeedback(ctx context.Context, input SubmitFeedbackInput) (model.Bid, error)
+	GetBidReviews(ctx context.Context, input GetBidReviewsInput) ([]model.BidReview, error)
+}
+
+type IAuthUsecase interface {
+	Login(ctx context.Context, input LoginInput) (model.AuthToken, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (model.Principal, error)
+}
+
+type IAPIKeyUsecase interface {
+	CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (model.IssuedAPIKey, error)
+	GetAPIKeys(ctx context.Context, input GetAPIKeysInput) ([]model.APIKey, error)
+	RevokeAPIKey(ctx context.Context, input RevokeAPIKeyInput) (model.APIKey, error)
+}
+
+type IMemberUsecase interface {
+	GetMembers(ctx context.Context, input GetMembersInput) ([]model.OrganizationMember, error)
+	GrantRole(ctx context.Context, input GrantRoleInput) (model.OrganizationMember, error)
+	RevokeMember(ctx context.Context, input RevokeMemberInput) error
+}
+
+type IAuditUsecase interface {
+	GetAuditEvents(ctx context.Context, input repository.GetAuditEventsInput) ([]model.AuditEvent, error)
+}
+
+type ICriterionUsecase interface {
+	GetTenderCriteria(ctx context.Context, input GetTenderCriteriaInput) ([]model.Criterion, error)
+	SetTenderCriteria(ctx context.Context, input SetTenderCriteriaInput) ([]model.Criterion, error)
+	SubmitScores(ctx context.Context, input SubmitScoresInput) ([]model.BidScore, error)
+	GetTenderRanking(ctx context.Context, input GetTenderRankingInput) ([]model.BidRanking, error)
+}
+
+type ILotUsecase interface {
+	GetTenderLots(ctx context.Context, input GetTenderLotsInput) ([]model.Lot, error)
+	CreateLot(ctx context.Context, input CreateLotInput) (model.Lot, error)
+	UpdateLot(ctx context.Context, input UpdateLotInput) (model.Lot, error)
+	RollbackLot(ctx context.Context, input RollbackLotInput) (model.Lot, error)
+	GetLotVersions(ctx context.Context, input GetLotVersionsInput) ([]model.LotVersion, error)
+}
+
+type IAttachmentUsecase interface {
+	AddTenderAttachment(ctx context.Context, input AddAttachmentInput) (model.Tender, error)
+	RemoveTenderAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Tender, error)
+	GetTenderAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error)
+	OpenTenderAttachment(ctx context.Context, input GetAttachmentInput) (AttachmentContent, error)
+	AddBidAttachment(ctx context.Context, input AddAttachmentInput) (model.Bid, error)
+	RemoveBidAttachment(ctx context.Context, input RemoveAttachmentInput) (model.Bid, error)
+	GetBidAttachments(ctx context.Context, input GetAttachmentsInput) ([]model.Attachment, error)
+	OpenBidAttachment(ctx context.Context, input GetAttachmentInput) (AttachmentContent, error)
+}
+
+type IQuestionUsecase interface {
+	AskQuestion(ctx context.Context, input AskQuestionInput) (model.Question, error)
+	GetTenderQuestions(ctx context.Context, input GetTenderQuestionsInput) ([]model.Question, error)
+	AnswerQuestion(ctx context.Context, input AnswerQuestionInput) (model.Question, error)
+}
+
+type IInvitationUsecase interface {
+	GetTenderInvitations(ctx context.Context, input GetTenderInvitationsInput) ([]model.Invitation, error)
+	CreateInvitation(ctx context.Context, input CreateInvitationInput) (model.Invitation, error)
+	RemoveInvitation(ctx context.Context, input RemoveInvitationInput) error
+}
+
+type IAuctionUsecase interface {
+	GetAuction(ctx context.Context, input GetAuctionInput) (model.Auction, error)
 }
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE bid_decision_type AS ENUM (
+    'Approved',
+    'Rejected'
+);
+
+CREATE TABLE IF NOT EXISTS bid_decision (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    decision bid_decision_type NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (bid_id, user_id)
+);
+
+CREATE INDEX bid_decision_bid_id_idx ON bid_decision(bid_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS bid_decision_bid_id_idx;
+DROP TABLE IF EXISTS bid_decision;
+DROP TYPE IF EXISTS bid_decision_type;
+
+-- +goose StatementEnd
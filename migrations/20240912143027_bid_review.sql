@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS bid_review (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    description VARCHAR(1000) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX bid_review_bid_id_idx ON bid_review(bid_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS bid_review_bid_id_idx;
+DROP TABLE IF EXISTS bid_review;
+
+-- +goose StatementEnd
@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- renumber versions duplicated by concurrent edits before enforcing uniqueness
+UPDATE tender_version tv
+SET version = n.rn
+FROM (
+    SELECT ctid, ROW_NUMBER() OVER (PARTITION BY tender_id ORDER BY version, created_at) AS rn
+    FROM tender_version
+    WHERE tender_id IN (
+        SELECT tender_id FROM tender_version GROUP BY tender_id, version HAVING COUNT(*) > 1
+    )
+) n
+WHERE tv.ctid = n.ctid;
+
+UPDATE bid_version bv
+SET version = n.rn
+FROM (
+    SELECT ctid, ROW_NUMBER() OVER (PARTITION BY bid_id ORDER BY version, created_at) AS rn
+    FROM bid_version
+    WHERE bid_id IN (
+        SELECT bid_id FROM bid_version GROUP BY bid_id, version HAVING COUNT(*) > 1
+    )
+) n
+WHERE bv.ctid = n.ctid;
+
+ALTER TABLE tender_version ADD CONSTRAINT tender_version_tender_id_version_key UNIQUE (tender_id, version);
+ALTER TABLE bid_version ADD CONSTRAINT bid_version_bid_id_version_key UNIQUE (bid_id, version);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE bid_version DROP CONSTRAINT IF EXISTS bid_version_bid_id_version_key;
+ALTER TABLE tender_version DROP CONSTRAINT IF EXISTS tender_version_tender_id_version_key;
+
+-- +goose StatementEnd
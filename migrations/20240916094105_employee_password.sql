@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- bcrypt hash, e.g. crypt('password', gen_salt('bf')) from pgcrypto
+ALTER TABLE employee ADD COLUMN IF NOT EXISTS password_hash VARCHAR(100);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE employee DROP COLUMN IF EXISTS password_hash;
+
+-- +goose StatementEnd
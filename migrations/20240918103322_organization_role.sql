@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE organization_role AS ENUM (
+    'Admin',
+    'TenderManager',
+    'Reviewer',
+    'Viewer'
+);
+
+-- existing responsibles keep full rights
+ALTER TABLE organization_responsible ADD COLUMN IF NOT EXISTS role organization_role NOT NULL DEFAULT 'Admin';
+
+DELETE FROM organization_responsible r
+USING organization_responsible d
+WHERE r.organization_id = d.organization_id
+    AND r.user_id = d.user_id
+    AND r.ctid > d.ctid;
+
+ALTER TABLE organization_responsible
+    ADD CONSTRAINT organization_responsible_organization_id_user_id_key UNIQUE (organization_id, user_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE organization_responsible DROP CONSTRAINT IF EXISTS organization_responsible_organization_id_user_id_key;
+ALTER TABLE organization_responsible DROP COLUMN IF EXISTS role;
+DROP TYPE IF EXISTS organization_role;
+
+-- +goose StatementEnd
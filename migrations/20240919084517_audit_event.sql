@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS audit_event (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id UUID NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor_id UUID,
+    api_key_id UUID,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(64),
+    created_at TIMESTAMP DEFAULT clock_timestamp()
+);
+
+CREATE INDEX audit_event_entity_idx ON audit_event(entity_type, entity_id, created_at);
+
+-- audit is append-only
+CREATE OR REPLACE FUNCTION audit_event_immutable() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_event is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_event_immutable
+    BEFORE UPDATE OR DELETE ON audit_event
+    FOR EACH ROW EXECUTE FUNCTION audit_event_immutable();
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TRIGGER IF EXISTS audit_event_immutable ON audit_event;
+DROP FUNCTION IF EXISTS audit_event_immutable();
+DROP INDEX IF EXISTS audit_event_entity_idx;
+DROP TABLE IF EXISTS audit_event;
+
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tender_version ADD COLUMN IF NOT EXISTS submission_deadline TIMESTAMPTZ;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE tender_version DROP COLUMN IF EXISTS submission_deadline;
+
+-- +goose StatementEnd
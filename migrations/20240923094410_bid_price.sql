@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE bid_version
+    ADD COLUMN IF NOT EXISTS price NUMERIC CHECK (price > 0),
+    ADD COLUMN IF NOT EXISTS currency CHAR(3) CHECK (currency ~ '^[A-Z]{3}$'),
+    ADD CONSTRAINT bid_version_price_currency_check CHECK ((price IS NULL) = (currency IS NULL));
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE bid_version
+    DROP CONSTRAINT IF EXISTS bid_version_price_currency_check,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS price;
+
+-- +goose StatementEnd
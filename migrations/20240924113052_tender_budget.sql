@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tender_version
+    ADD COLUMN IF NOT EXISTS budget NUMERIC CHECK (budget > 0),
+    ADD COLUMN IF NOT EXISTS budget_currency CHAR(3) CHECK (budget_currency ~ '^[A-Z]{3}$'),
+    ADD COLUMN IF NOT EXISTS enforce_budget BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT tender_version_budget_currency_check CHECK ((budget IS NULL) = (budget_currency IS NULL)),
+    ADD CONSTRAINT tender_version_enforce_budget_check CHECK (NOT enforce_budget OR budget IS NOT NULL);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE tender_version
+    DROP CONSTRAINT IF EXISTS tender_version_enforce_budget_check,
+    DROP CONSTRAINT IF EXISTS tender_version_budget_currency_check,
+    DROP COLUMN IF EXISTS enforce_budget,
+    DROP COLUMN IF EXISTS budget_currency,
+    DROP COLUMN IF EXISTS budget;
+
+-- +goose StatementEnd
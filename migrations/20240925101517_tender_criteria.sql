@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS tender_criterion (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    weight INT NOT NULL CHECK (weight BETWEEN 1 AND 100),
+    position INT NOT NULL,
+    UNIQUE (tender_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS bid_score (
+    bid_id UUID NOT NULL REFERENCES bid(id) ON DELETE CASCADE,
+    criterion_id UUID NOT NULL REFERENCES tender_criterion(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    score INT NOT NULL CHECK (score BETWEEN 0 AND 10),
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (bid_id, criterion_id, author_id)
+);
+
+CREATE INDEX bid_score_criterion_id_idx ON bid_score(criterion_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS bid_score_criterion_id_idx;
+DROP TABLE IF EXISTS bid_score;
+DROP TABLE IF EXISTS tender_criterion;
+
+-- +goose StatementEnd
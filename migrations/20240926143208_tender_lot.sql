@@ -0,0 +1,45 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TYPE lot_status AS ENUM (
+    'Open',
+    'Awarded'
+);
+
+CREATE TABLE IF NOT EXISTS lot (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    status lot_status NOT NULL DEFAULT 'Open',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS lot_version (
+    lot_id UUID REFERENCES lot(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL,
+    service_type tender_service_type NOT NULL,
+    version INTEGER NOT NULL DEFAULT 1 CHECK ( version >= 1 ),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (lot_id, version)
+);
+
+ALTER TABLE bid ADD COLUMN IF NOT EXISTS lot_id UUID REFERENCES lot(id) ON DELETE CASCADE;
+ALTER TABLE lot ADD COLUMN IF NOT EXISTS winner_bid_id UUID REFERENCES bid(id) ON DELETE SET NULL;
+
+CREATE INDEX lot_tender_id_idx ON lot(tender_id);
+CREATE INDEX bid_lot_id_idx ON bid(lot_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS bid_lot_id_idx;
+DROP INDEX IF EXISTS lot_tender_id_idx;
+ALTER TABLE lot DROP COLUMN IF EXISTS winner_bid_id;
+ALTER TABLE bid DROP COLUMN IF EXISTS lot_id;
+DROP TABLE IF EXISTS lot_version;
+DROP TABLE IF EXISTS lot;
+DROP TYPE IF EXISTS lot_status;
+
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS attachment (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    storage_key VARCHAR(64) UNIQUE NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size >= 0),
+    checksum CHAR(64) NOT NULL,
+    author_id UUID REFERENCES employee(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- attachment sets are kept per version, so rollback restores them with the rest of the fields
+
+CREATE TABLE IF NOT EXISTS tender_attachment (
+    tender_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    attachment_id UUID NOT NULL REFERENCES attachment(id) ON DELETE CASCADE,
+    PRIMARY KEY (tender_id, version, attachment_id),
+    FOREIGN KEY (tender_id, version) REFERENCES tender_version(tender_id, version) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS bid_attachment (
+    bid_id UUID NOT NULL,
+    version INTEGER NOT NULL,
+    attachment_id UUID NOT NULL REFERENCES attachment(id) ON DELETE CASCADE,
+    PRIMARY KEY (bid_id, version, attachment_id),
+    FOREIGN KEY (bid_id, version) REFERENCES bid_version(bid_id, version) ON DELETE CASCADE
+);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE IF EXISTS bid_attachment;
+DROP TABLE IF EXISTS tender_attachment;
+DROP TABLE IF EXISTS attachment;
+
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS tender_question (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tender_id UUID NOT NULL REFERENCES tender(id) ON DELETE CASCADE,
+    author_id UUID NOT NULL REFERENCES employee(id) ON DELETE CASCADE,
+    question VARCHAR(1000) NOT NULL,
+    answer VARCHAR(1000),
+    answered_by UUID REFERENCES employee(id) ON DELETE SET NULL,
+    answered_at TIMESTAMP,
+    public BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (NOT public OR answer IS NOT NULL)
+);
+
+CREATE INDEX tender_question_tender_id_idx ON tender_question(tender_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS tender_question_tender_id_idx;
+DROP TABLE IF EXISTS tender_question;
+
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE tender
+    ADD COLUMN IF NOT EXISTS auction BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS auction_start TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS auction_step NUMERIC CHECK (auction_step > 0),
+    ADD COLUMN IF NOT EXISTS auction_extension INTEGER NOT NULL DEFAULT 0 CHECK (auction_extension >= 0),
+    ADD CONSTRAINT tender_auction_step_check CHECK (NOT auction OR auction_step IS NOT NULL);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE tender
+    DROP CONSTRAINT IF EXISTS tender_auction_step_check,
+    DROP COLUMN IF EXISTS auction_extension,
+    DROP COLUMN IF EXISTS auction_step,
+    DROP COLUMN IF EXISTS auction_start,
+    DROP COLUMN IF EXISTS auction;
+
+-- +goose StatementEnd
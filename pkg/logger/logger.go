@@ -13,6 +13,8 @@ const (
 
 type ctxLogger struct{}
 
+type ctxRequestID struct{}
+
 func NewLogger(env string) *slog.Logger {
 	log := &slog.Logger{}
 
@@ -59,3 +61,14 @@ func getLoggerFromContext(ctx context.Context) *slog.Logger {
 	}
 	return DefaultLogger()
 }
+
+// WithRequestID adds ID of the request being served to context.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, ctxRequestID{}, id)
+}
+
+// RequestID returns ID of the request being served or empty string.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(ctxRequestID{}).(string)
+	return id
+}